@@ -0,0 +1,65 @@
+package main
+
+import (
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/ZaneH/defuse.party-tui/internal/fakebackend"
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+func main() {
+	const (
+		defaultAddr = "localhost:50051"
+		defaultSeed = "1"
+	)
+
+	addr := getEnvOrDefault("FAKE_BACKEND_ADDR", defaultAddr)
+	seed, err := strconv.ParseInt(getEnvOrDefault("FAKE_BACKEND_SEED", defaultSeed), 10, 64)
+	if err != nil {
+		log.Fatalf("invalid FAKE_BACKEND_SEED: %v", err)
+	}
+
+	script, err := fakebackend.ParseScript(os.Getenv("FAKE_BACKEND_SCRIPT"))
+	if err != nil {
+		log.Fatalf("invalid FAKE_BACKEND_SCRIPT: %v", err)
+	}
+
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+
+	s := grpc.NewServer()
+	pb.RegisterGameServiceServer(s, fakebackend.New(seed, fakebackend.WithScript(script)))
+	healthpb.RegisterHealthServer(s, health.NewServer())
+
+	done := make(chan os.Signal, 1)
+	signal.Notify(done, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		log.Printf("fake game backend listening on %s (seed %d)", lis.Addr(), seed)
+		if err := s.Serve(lis); err != nil {
+			log.Fatalf("server error: %v", err)
+		}
+	}()
+
+	<-done
+	log.Println("shutting down fake backend...")
+	s.GracefulStop()
+}
+
+func getEnvOrDefault(key, defaultVal string) string {
+	if val := os.Getenv(key); val != "" {
+		return val
+	}
+	return defaultVal
+}
@@ -7,31 +7,87 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
+	"time"
 
+	"github.com/charmbracelet/ssh"
 	"github.com/charmbracelet/wish"
 	"github.com/charmbracelet/wish/bubbletea"
 	"github.com/charmbracelet/wish/logging"
 	"github.com/muesli/termenv"
+	gossh "golang.org/x/crypto/ssh"
 
+	"github.com/ZaneH/defuse.party-tui/internal/client"
+	"github.com/ZaneH/defuse.party-tui/internal/profile"
 	"github.com/ZaneH/defuse.party-tui/internal/tui"
 )
 
 func main() {
 	const (
-		host       = "0.0.0.0"
-		defaultSSH = "2222"
-		defaultRPC = "localhost:50051"
+		host        = "0.0.0.0"
+		defaultSSH  = "2222"
+		defaultRPC  = "localhost:50051"
+		defaultData = "data"
+
+		defaultResyncInterval = 5 * time.Second
 	)
 
 	sshPort := getEnvOrDefault("TUI_SSH_PORT", defaultSSH)
 	grpcAddr := getEnvOrDefault("TUI_GRPC_ADDR", defaultRPC)
+	resyncInterval := getDurationEnvOrDefault("TUI_RESYNC_INTERVAL", defaultResyncInterval)
+	keepalive := getDurationEnvOrDefault("TUI_GRPC_KEEPALIVE", client.DefaultKeepalive)
+	rpcTimeout := getDurationEnvOrDefault("TUI_GRPC_TIMEOUT", client.DefaultTimeout)
+	dataDir := getEnvOrDefault("TUI_DATA_DIR", defaultData)
+
+	tuiConfig := tui.Config{
+		ResyncInterval: resyncInterval,
+	}
+	if path := os.Getenv("TUI_MISSIONS_FILE"); path != "" {
+		missions, err := tui.LoadMissionCatalog(path)
+		if err != nil {
+			log.Fatalf("invalid mission catalog:\n%v", err)
+		}
+		tuiConfig.Missions = missions
+	}
+
+	clientOpts := []client.Option{client.WithKeepalive(keepalive), client.WithTimeout(rpcTimeout)}
+	tlsConfig := client.TLSConfig{
+		CAFile:     os.Getenv("TUI_GRPC_CA_FILE"),
+		CertFile:   os.Getenv("TUI_GRPC_CERT_FILE"),
+		KeyFile:    os.Getenv("TUI_GRPC_KEY_FILE"),
+		ServerName: os.Getenv("TUI_GRPC_SERVER_NAME"),
+	}
+	if getBoolEnvOrDefault("TUI_GRPC_TLS", false) || tlsConfig != (client.TLSConfig{}) {
+		clientOpts = append(clientOpts, client.WithTLS(tlsConfig))
+	}
+	if token := os.Getenv("TUI_GRPC_TOKEN"); token != "" {
+		clientOpts = append(clientOpts, client.WithToken(token))
+	}
+
+	clients, err := client.NewManager(grpcAddr, clientOpts...)
+	if err != nil {
+		log.Fatalf("failed to create game client: %v", err)
+	}
+
+	profiles, err := profile.Open(dataDir)
+	if err != nil {
+		log.Fatalf("failed to open profiles: %v", err)
+	}
 
 	s, err := wish.NewServer(
 		wish.WithAddress(fmt.Sprintf("%s:%s", host, sshPort)),
 		wish.WithHostKeyPath(".ssh/id_ed25519"),
+		// Anyone may play. Accepting every public key lets clients present
+		// one so their profile follows them; keyless clients fall through to
+		// keyboard-interactive and are known by username.
+		wish.WithPublicKeyAuth(func(ssh.Context, ssh.PublicKey) bool { return true }),
+		wish.WithKeyboardInteractiveAuth(func(ssh.Context, gossh.KeyboardInteractiveChallenge) bool { return true }),
 		wish.WithMiddleware(
-			bubbletea.MiddlewareWithProgramHandler(tui.NewProgramHandler(grpcAddr), termenv.ANSI256),
+			// The profile only affects renderers made with MakeRenderer, which
+			// the UI doesn't use: it renders in true color and converts each
+			// session's frames for its terminal itself.
+			bubbletea.MiddlewareWithProgramHandler(tui.NewProgramHandler(tuiConfig, clients, profiles), termenv.TrueColor),
 			logging.Middleware(),
 		),
 	)
@@ -54,6 +110,12 @@ func main() {
 	if err := s.Shutdown(context.Background()); err != nil {
 		log.Fatalf("server shutdown error: %v", err)
 	}
+	if err := clients.Close(); err != nil {
+		log.Printf("failed to close game client: %v", err)
+	}
+	if err := profiles.Close(); err != nil {
+		log.Printf("failed to close profiles: %v", err)
+	}
 }
 
 func getEnvOrDefault(key, defaultVal string) string {
@@ -62,3 +124,27 @@ func getEnvOrDefault(key, defaultVal string) string {
 	}
 	return defaultVal
 }
+
+func getDurationEnvOrDefault(key string, defaultVal time.Duration) time.Duration {
+	val := os.Getenv(key)
+	if val == "" {
+		return defaultVal
+	}
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		log.Fatalf("invalid %s %q: %v", key, val, err)
+	}
+	return d
+}
+
+func getBoolEnvOrDefault(key string, defaultVal bool) bool {
+	val := os.Getenv(key)
+	if val == "" {
+		return defaultVal
+	}
+	b, err := strconv.ParseBool(val)
+	if err != nil {
+		log.Fatalf("invalid %s %q: %v", key, val, err)
+	}
+	return b
+}
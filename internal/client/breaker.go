@@ -0,0 +1,196 @@
+package client
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrCircuitOpen is returned without contacting the backend while the
+// circuit breaker is open.
+var ErrCircuitOpen = errors.New("backend unavailable, circuit breaker open")
+
+// RetryPolicy controls how idempotent calls are retried after transient
+// failures. Delays grow exponentially from BaseDelay up to MaxDelay, with
+// full jitter so that many sessions don't retry in lockstep.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   200 * time.Millisecond,
+	MaxDelay:    2 * time.Second,
+}
+
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(delay)) + 1)
+}
+
+// BreakerConfig controls the circuit breaker. After Threshold consecutive
+// transient failures calls fail fast for Cooldown, after which a single
+// trial call decides whether to close the circuit again. A zero Threshold
+// disables the breaker.
+type BreakerConfig struct {
+	Threshold int
+	Cooldown  time.Duration
+}
+
+var DefaultBreakerConfig = BreakerConfig{
+	Threshold: 5,
+	Cooldown:  10 * time.Second,
+}
+
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	BreakerOpen
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "closed"
+}
+
+// Status summarises recent backend health for display.
+type Status struct {
+	State BreakerState
+
+	// Failures counts consecutive transient failures; LastErr is the most
+	// recent of them.
+	Failures int
+	LastErr  error
+
+	// RetryAt is when an open breaker lets the next call through.
+	RetryAt time.Time
+}
+
+// Healthy reports whether the last call to the backend succeeded.
+func (s Status) Healthy() bool {
+	return s.State == BreakerClosed && s.Failures == 0
+}
+
+// StatusReporter is implemented by clients that track backend health.
+type StatusReporter interface {
+	Status() Status
+}
+
+type breaker struct {
+	mu     sync.Mutex
+	config BreakerConfig
+	now    func() time.Time
+
+	status Status
+	trial  bool
+}
+
+func newBreaker(config BreakerConfig) *breaker {
+	return &breaker{config: config, now: time.Now}
+}
+
+// allow reports whether a call may go ahead.
+func (b *breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.status.State {
+	case BreakerOpen:
+		if b.now().Before(b.status.RetryAt) {
+			return ErrCircuitOpen
+		}
+		b.status.State = BreakerHalfOpen
+		b.trial = true
+		return nil
+	case BreakerHalfOpen:
+		if b.trial {
+			return ErrCircuitOpen
+		}
+		b.trial = true
+	}
+	return nil
+}
+
+// record updates the breaker with the outcome of a call. Only transient
+// failures count against the backend; errors such as NotFound mean it
+// answered.
+func (b *breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+
+	if status.Code(err) == codes.Canceled {
+		// The caller gave up, which says nothing about the backend.
+		return
+	}
+	if !isTransient(err) {
+		b.status = Status{}
+		return
+	}
+
+	b.status.Failures++
+	b.status.LastErr = err
+	if b.config.Threshold > 0 && (b.status.State == BreakerHalfOpen || b.status.Failures >= b.config.Threshold) {
+		b.status.State = BreakerOpen
+		b.status.RetryAt = b.now().Add(b.config.Cooldown)
+	}
+}
+
+func (b *breaker) Status() Status {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.status
+}
+
+// isTransient reports whether err suggests the backend is unreachable or
+// overloaded rather than rejecting the request.
+func isTransient(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	}
+	return false
+}
+
+// Describe turns a client error into a short message for players.
+func Describe(err error) string {
+	if errors.Is(err, ErrCircuitOpen) {
+		return "backend unreachable"
+	}
+	switch status.Code(err) {
+	case codes.Unavailable:
+		return "backend unreachable"
+	case codes.DeadlineExceeded:
+		return "backend timed out"
+	case codes.ResourceExhausted:
+		return "backend overloaded"
+	case codes.NotFound:
+		return "game no longer exists on the backend"
+	}
+	if s, ok := status.FromError(err); ok {
+		return s.Message()
+	}
+	return err.Error()
+}
@@ -0,0 +1,192 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+// flakyBackend fails the first failures calls to each method with code and
+// counts every call it sees.
+type flakyBackend struct {
+	mu       sync.Mutex
+	failures int
+	code     codes.Code
+	calls    map[string]int
+}
+
+func (f *flakyBackend) interceptor() grpc.ServerOption {
+	f.calls = make(map[string]int)
+	return grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		method := info.FullMethod[strings.LastIndex(info.FullMethod, "/")+1:]
+		f.mu.Lock()
+		f.calls[method]++
+		fail := f.calls[method] <= f.failures
+		f.mu.Unlock()
+
+		if fail {
+			return nil, status.Error(f.code, "flaky")
+		}
+		return handler(ctx, req)
+	})
+}
+
+func (f *flakyBackend) setFailures(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures = n
+	f.calls = make(map[string]int)
+}
+
+func (f *flakyBackend) count(method string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[method]
+}
+
+var fastRetry = WithRetry(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond})
+
+func TestGetBombsRetriesTransientFailures(t *testing.T) {
+	flaky := &flakyBackend{failures: 2, code: codes.Unavailable}
+	addr := startBackend(t, flaky.interceptor())
+
+	c, err := New(addr, fastRetry)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer c.Close()
+	ctx := context.Background()
+
+	sessionID, err := c.CreateGame(ctx, &pb.GameConfig{})
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("CreateGame: err = %v, want Unavailable without a retry", err)
+	}
+	flaky.setFailures(0)
+	if sessionID, err = c.CreateGame(ctx, &pb.GameConfig{}); err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+
+	flaky.setFailures(2)
+	if _, err := c.GetBombs(ctx, sessionID); err != nil {
+		t.Fatalf("GetBombs: %v", err)
+	}
+	if got := flaky.count("GetBombs"); got != 3 {
+		t.Errorf("GetBombs called %d times, want 3", got)
+	}
+	if status := c.(StatusReporter).Status(); !status.Healthy() {
+		t.Errorf("status after recovery = %+v, want healthy", status)
+	}
+}
+
+func TestGetBombsDoesNotRetryRejections(t *testing.T) {
+	flaky := &flakyBackend{}
+	addr := startBackend(t, flaky.interceptor())
+
+	c, err := New(addr, fastRetry)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer c.Close()
+
+	if _, err := c.GetBombs(context.Background(), "no-such-session"); status.Code(err) != codes.NotFound {
+		t.Fatalf("GetBombs: err = %v, want NotFound", err)
+	}
+	if got := flaky.count("GetBombs"); got != 1 {
+		t.Errorf("GetBombs called %d times, want 1", got)
+	}
+}
+
+func TestSendInputIsNotRetried(t *testing.T) {
+	flaky := &flakyBackend{failures: 1, code: codes.Unavailable}
+	addr := startBackend(t, flaky.interceptor())
+
+	c, err := New(addr, fastRetry)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer c.Close()
+
+	if _, err := c.SendInput(context.Background(), &pb.PlayerInput{}); status.Code(err) != codes.Unavailable {
+		t.Fatalf("SendInput: err = %v, want Unavailable", err)
+	}
+	if got := flaky.count("SendInput"); got != 1 {
+		t.Errorf("SendInput called %d times, want 1", got)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := newBreaker(BreakerConfig{Threshold: 2, Cooldown: time.Minute})
+	b.now = func() time.Time { return now }
+	unavailable := status.Error(codes.Unavailable, "down")
+
+	b.record(unavailable)
+	if err := b.allow(); err != nil {
+		t.Fatalf("breaker opened below the threshold: %v", err)
+	}
+	b.record(status.Error(codes.Canceled, "player left"))
+	b.record(unavailable)
+	if s := b.Status(); s.State != BreakerOpen || s.Failures != 2 || !s.RetryAt.Equal(now.Add(time.Minute)) {
+		t.Fatalf("status = %+v, want open after 2 failures", s)
+	}
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("allow while open: err = %v", err)
+	}
+
+	// After the cooldown a single trial call is let through.
+	now = now.Add(time.Minute)
+	if err := b.allow(); err != nil {
+		t.Fatalf("trial call: %v", err)
+	}
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("second call during trial: err = %v", err)
+	}
+	b.record(unavailable)
+	if s := b.Status(); s.State != BreakerOpen || !s.RetryAt.Equal(now.Add(time.Minute)) {
+		t.Fatalf("status = %+v, want reopened after a failed trial", s)
+	}
+
+	now = now.Add(time.Minute)
+	if err := b.allow(); err != nil {
+		t.Fatalf("trial call: %v", err)
+	}
+	b.record(status.Error(codes.NotFound, "no such session"))
+	if s := b.Status(); !s.Healthy() {
+		t.Fatalf("status = %+v, want closed once the backend answers", s)
+	}
+}
+
+func TestManagerClientsShareBreaker(t *testing.T) {
+	flaky := &flakyBackend{failures: 100, code: codes.Unavailable}
+	addr := startBackend(t, flaky.interceptor())
+
+	m, err := NewManager(addr, WithBreaker(BreakerConfig{Threshold: 1, Cooldown: time.Minute}))
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	defer m.Close()
+
+	first, _ := m.Client()
+	second, _ := m.Client()
+	if _, err := first.SendInput(context.Background(), &pb.PlayerInput{}); status.Code(err) != codes.Unavailable {
+		t.Fatalf("SendInput: err = %v, want Unavailable", err)
+	}
+	if _, err := second.SendInput(context.Background(), &pb.PlayerInput{}); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("SendInput from another session: err = %v, want ErrCircuitOpen", err)
+	}
+	if got := flaky.count("SendInput"); got != 1 {
+		t.Errorf("backend saw %d calls, want 1", got)
+	}
+	if m.Status().State != BreakerOpen {
+		t.Errorf("manager status = %+v, want open", m.Status())
+	}
+}
@@ -0,0 +1,127 @@
+// Package clienttest provides a scriptable in-memory client.GameClient for
+// tests.
+package clienttest
+
+import (
+	"context"
+	"sync"
+
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+// Client records every call and answers from canned responses. Results and
+// errors queued with QueueResult and QueueError are returned in order; once
+// the queue is empty, SendInput falls back to OnInput if set, or to an empty
+// result for the input's module.
+type Client struct {
+	mu sync.Mutex
+
+	SessionID string
+	Bombs     []*pb.Bomb
+
+	// CreateErr and GetBombsErr, when set, are returned by the matching call.
+	CreateErr   error
+	GetBombsErr error
+
+	// OnInput answers inputs once the queue is drained.
+	OnInput func(input *pb.PlayerInput) (*pb.PlayerInputResult, error)
+
+	queue   []response
+	inputs  []*pb.PlayerInput
+	configs []*pb.GameConfig
+	closed  bool
+}
+
+type response struct {
+	result *pb.PlayerInputResult
+	err    error
+}
+
+func New(bombs ...*pb.Bomb) *Client {
+	return &Client{
+		SessionID: "test-session",
+		Bombs:     bombs,
+	}
+}
+
+// QueueResult appends canned results for upcoming SendInput calls.
+func (c *Client) QueueResult(results ...*pb.PlayerInputResult) *Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, result := range results {
+		c.queue = append(c.queue, response{result: result})
+	}
+	return c
+}
+
+// QueueError makes the next SendInput call fail with err.
+func (c *Client) QueueError(err error) *Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.queue = append(c.queue, response{err: err})
+	return c
+}
+
+// Inputs returns every input sent so far.
+func (c *Client) Inputs() []*pb.PlayerInput {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*pb.PlayerInput(nil), c.inputs...)
+}
+
+// Configs returns the config of every CreateGame call so far.
+func (c *Client) Configs() []*pb.GameConfig {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*pb.GameConfig(nil), c.configs...)
+}
+
+func (c *Client) Closed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+func (c *Client) CreateGame(ctx context.Context, config *pb.GameConfig) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.configs = append(c.configs, config)
+	if c.CreateErr != nil {
+		return "", c.CreateErr
+	}
+	return c.SessionID, nil
+}
+
+func (c *Client) GetBombs(ctx context.Context, sessionID string) ([]*pb.Bomb, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.GetBombsErr != nil {
+		return nil, c.GetBombsErr
+	}
+	return c.Bombs, nil
+}
+
+func (c *Client) SendInput(ctx context.Context, input *pb.PlayerInput) (*pb.PlayerInputResult, error) {
+	c.mu.Lock()
+	c.inputs = append(c.inputs, input)
+	if len(c.queue) > 0 {
+		next := c.queue[0]
+		c.queue = c.queue[1:]
+		c.mu.Unlock()
+		return next.result, next.err
+	}
+	onInput := c.OnInput
+	c.mu.Unlock()
+
+	if onInput != nil {
+		return onInput(input)
+	}
+	return &pb.PlayerInputResult{ModuleId: input.GetModuleId()}, nil
+}
+
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return nil
+}
@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 
 	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
 )
@@ -18,29 +17,61 @@ type GameClient interface {
 	Close() error
 }
 
+// grpcClient talks to the backend over conn. conn is nil for clients handed
+// out by a Manager, which owns the connection instead. Every call counts
+// towards breaker, which is shared by all clients on the same connection.
 type grpcClient struct {
-	conn   *grpc.ClientConn
-	client pb.GameServiceClient
+	conn    *grpc.ClientConn
+	client  pb.GameServiceClient
+	timeout time.Duration
+	retry   RetryPolicy
+	breaker *breaker
 }
 
-func New(addr string) (GameClient, error) {
-	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// New opens a dedicated connection to addr that is closed along with the
+// client. Servers handling many sessions should share one through a Manager.
+func New(addr string, opts ...Option) (GameClient, error) {
+	o := newOptions(opts)
+	dialOpts, err := o.dialOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.NewClient(addr, dialOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to gRPC server: %w", err)
 	}
 
 	return &grpcClient{
-		conn:   conn,
-		client: pb.NewGameServiceClient(conn),
+		conn:    conn,
+		client:  pb.NewGameServiceClient(conn),
+		timeout: o.timeout,
+		retry:   o.retry,
+		breaker: newBreaker(o.breaker),
 	}, nil
 }
 
-func (c *grpcClient) CreateGame(ctx context.Context, config *pb.GameConfig) (string, error) {
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+// call runs one attempt of an RPC through the circuit breaker.
+func (c *grpcClient) call(ctx context.Context, rpc func(ctx context.Context) error) error {
+	if err := c.breaker.allow(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
-	resp, err := c.client.CreateGame(ctx, &pb.CreateGameRequest{
-		Config: config,
+	err := rpc(ctx)
+	c.breaker.record(err)
+	return err
+}
+
+func (c *grpcClient) CreateGame(ctx context.Context, config *pb.GameConfig) (string, error) {
+	// Loading a game waits out a reconnect rather than failing straight
+	// away; the player is watching a loading screen anyway.
+	var resp *pb.CreateGameResponse
+	err := c.call(ctx, func(ctx context.Context) (err error) {
+		resp, err = c.client.CreateGame(ctx, &pb.CreateGameRequest{Config: config}, grpc.WaitForReady(true))
+		return err
 	})
 	if err != nil {
 		return "", fmt.Errorf("failed to create game: %w", err)
@@ -49,11 +80,25 @@ func (c *grpcClient) CreateGame(ctx context.Context, config *pb.GameConfig) (str
 	return resp.SessionId, nil
 }
 
+// GetBombs is read-only, so transient failures are retried.
 func (c *grpcClient) GetBombs(ctx context.Context, sessionID string) ([]*pb.Bomb, error) {
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-
-	resp, err := c.client.GetBombs(ctx, &pb.GetBombsRequest{SessionId: sessionID})
+	var resp *pb.GetBombsResponse
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = c.call(ctx, func(ctx context.Context) (err error) {
+			resp, err = c.client.GetBombs(ctx, &pb.GetBombsRequest{SessionId: sessionID}, grpc.WaitForReady(true))
+			return err
+		})
+		if err == nil || !isTransient(err) || attempt >= c.retry.MaxAttempts {
+			break
+		}
+
+		select {
+		case <-time.After(c.retry.backoff(attempt)):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to get bombs: %w", ctx.Err())
+		}
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get bombs: %w", err)
 	}
@@ -61,13 +106,25 @@ func (c *grpcClient) GetBombs(ctx context.Context, sessionID string) ([]*pb.Bomb
 	return resp.Bombs, nil
 }
 
+// SendInput fails fast while the connection is down so the player hears
+// about it straight away. Inputs are never retried: the backend may already
+// have applied one whose response was lost.
 func (c *grpcClient) SendInput(ctx context.Context, input *pb.PlayerInput) (*pb.PlayerInputResult, error) {
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
+	var result *pb.PlayerInputResult
+	err := c.call(ctx, func(ctx context.Context) (err error) {
+		result, err = c.client.SendInput(ctx, input)
+		return err
+	})
+	return result, err
+}
 
-	return c.client.SendInput(ctx, input)
+func (c *grpcClient) Status() Status {
+	return c.breaker.Status()
 }
 
 func (c *grpcClient) Close() error {
+	if c.conn == nil {
+		return nil
+	}
 	return c.conn.Close()
 }
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+var ErrManagerClosed = errors.New("client manager is closed")
+
+// Manager owns a single multiplexed connection to the game backend that is
+// shared by every SSH session. Clients handed out by Client use that
+// connection and leave it open when closed; only Manager.Close tears it down.
+type Manager struct {
+	mu      sync.Mutex
+	conn    *grpc.ClientConn
+	client  pb.GameServiceClient
+	options options
+	breaker *breaker
+	closed  bool
+}
+
+func NewManager(addr string, opts ...Option) (*Manager, error) {
+	o := newOptions(opts)
+	dialOpts, err := o.dialOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.NewClient(addr, dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to gRPC server: %w", err)
+	}
+	// NewClient is lazy; start connecting now so the first game doesn't pay
+	// for the handshake.
+	conn.Connect()
+
+	return &Manager{
+		conn:    conn,
+		client:  pb.NewGameServiceClient(conn),
+		options: o,
+		breaker: newBreaker(o.breaker),
+	}, nil
+}
+
+// Client returns a GameClient backed by the shared connection.
+func (m *Manager) Client() (GameClient, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return nil, ErrManagerClosed
+	}
+	return &grpcClient{
+		client:  m.client,
+		timeout: m.options.timeout,
+		retry:   m.options.retry,
+		breaker: m.breaker,
+	}, nil
+}
+
+// Status reports the health of the shared connection as seen by its clients.
+func (m *Manager) Status() Status {
+	return m.breaker.Status()
+}
+
+// State reports the connectivity state of the shared connection.
+func (m *Manager) State() connectivity.State {
+	return m.conn.GetState()
+}
+
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return nil
+	}
+	m.closed = true
+	return m.conn.Close()
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/ZaneH/defuse.party-tui/internal/fakebackend"
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+func startBackend(t *testing.T, opts ...grpc.ServerOption) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s := grpc.NewServer(opts...)
+	pb.RegisterGameServiceServer(s, fakebackend.New(1))
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+	return lis.Addr().String()
+}
+
+func TestManagerSharesConnection(t *testing.T) {
+	m, err := NewManager(startBackend(t))
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	defer m.Close()
+
+	ctx := context.Background()
+	first, err := m.Client()
+	if err != nil {
+		t.Fatalf("Client: %v", err)
+	}
+	if _, err := first.CreateGame(ctx, &pb.GameConfig{}); err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+	if err := first.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	// Closing one session's client must leave the connection up for others.
+	second, err := m.Client()
+	if err != nil {
+		t.Fatalf("Client: %v", err)
+	}
+	sessionID, err := second.CreateGame(ctx, &pb.GameConfig{})
+	if err != nil {
+		t.Fatalf("CreateGame after another client closed: %v", err)
+	}
+	if _, err := second.GetBombs(ctx, sessionID); err != nil {
+		t.Fatalf("GetBombs: %v", err)
+	}
+	if state := m.State(); state != connectivity.Ready {
+		t.Errorf("connection state = %v, want READY", state)
+	}
+
+	if err := m.Close(); err != nil {
+		t.Fatalf("Manager.Close: %v", err)
+	}
+	if _, err := m.Client(); !errors.Is(err, ErrManagerClosed) {
+		t.Errorf("Client after Close: err = %v, want ErrManagerClosed", err)
+	}
+	if _, err := second.GetBombs(ctx, sessionID); err == nil {
+		t.Error("RPC succeeded on a closed connection")
+	}
+}
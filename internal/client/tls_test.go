@@ -0,0 +1,235 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+// testPKI is a throwaway CA with a server certificate for backend.internal
+// and a client certificate, all written to a temporary directory.
+type testPKI struct {
+	dir    string
+	pool   *x509.CertPool
+	server tls.Certificate
+}
+
+func newTestPKI(t *testing.T) *testPKI {
+	t.Helper()
+	dir := t.TempDir()
+
+	caKey, caCert := issue(t, nil, nil, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "test CA"},
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	})
+	serverKey, serverCert := issue(t, caKey, caCert, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "backend.internal"},
+		DNSNames:    []string{"backend.internal"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	clientKey, clientCert := issue(t, caKey, caCert, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "tui"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+
+	writePEM(t, filepath.Join(dir, "ca.pem"), "CERTIFICATE", caCert.Raw)
+	writePEM(t, filepath.Join(dir, "client.pem"), "CERTIFICATE", clientCert.Raw)
+	writeKey(t, filepath.Join(dir, "client-key.pem"), clientKey)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(caCert)
+	return &testPKI{
+		dir:  dir,
+		pool: pool,
+		server: tls.Certificate{
+			Certificate: [][]byte{serverCert.Raw},
+			PrivateKey:  serverKey,
+		},
+	}
+}
+
+func (p *testPKI) path(name string) string {
+	return filepath.Join(p.dir, name)
+}
+
+// serve starts a TLS backend. With mutual set, clients must present a
+// certificate signed by the test CA.
+func (p *testPKI) serve(t *testing.T, mutual bool, opts ...grpc.ServerOption) string {
+	config := &tls.Config{Certificates: []tls.Certificate{p.server}}
+	if mutual {
+		config.ClientCAs = p.pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return startBackend(t, append(opts, grpc.Creds(credentials.NewTLS(config)))...)
+}
+
+var serial int64
+
+func issue(t *testing.T, parentKey *ecdsa.PrivateKey, parent, template *x509.Certificate) (*ecdsa.PrivateKey, *x509.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serial++
+	template.SerialNumber = big.NewInt(serial)
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, cert
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeKey(t *testing.T, path string, key *ecdsa.PrivateKey) {
+	t.Helper()
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writePEM(t, path, "EC PRIVATE KEY", der)
+}
+
+// requireToken rejects RPCs that don't carry the expected bearer token.
+func requireToken(token string) grpc.ServerOption {
+	return grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if got := md.Get("authorization"); len(got) != 1 || got[0] != "Bearer "+token {
+			return nil, status.Error(codes.Unauthenticated, "bad token")
+		}
+		return handler(ctx, req)
+	})
+}
+
+func createGame(t *testing.T, addr string, opts ...Option) error {
+	t.Helper()
+	// CreateGame waits for the connection to be ready, so a failed
+	// handshake only surfaces once the deadline passes.
+	c, err := New(addr, append([]Option{WithTimeout(500 * time.Millisecond)}, opts...)...)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer c.Close()
+
+	_, err = c.CreateGame(context.Background(), &pb.GameConfig{})
+	return err
+}
+
+func TestTLS(t *testing.T) {
+	pki := newTestPKI(t)
+	addr := pki.serve(t, false)
+
+	tlsConfig := TLSConfig{CAFile: pki.path("ca.pem"), ServerName: "backend.internal"}
+	if err := createGame(t, addr, WithTLS(tlsConfig)); err != nil {
+		t.Fatalf("CreateGame over TLS: %v", err)
+	}
+
+	// The certificate is for backend.internal, not the address we dial.
+	if err := createGame(t, addr, WithTLS(TLSConfig{CAFile: pki.path("ca.pem")})); err == nil {
+		t.Error("expected a host name mismatch without a server name override")
+	}
+	if err := createGame(t, addr); err == nil {
+		t.Error("expected a cleartext client to be rejected")
+	}
+}
+
+func TestMutualTLS(t *testing.T) {
+	pki := newTestPKI(t)
+	addr := pki.serve(t, true)
+
+	tlsConfig := TLSConfig{
+		CAFile:     pki.path("ca.pem"),
+		CertFile:   pki.path("client.pem"),
+		KeyFile:    pki.path("client-key.pem"),
+		ServerName: "backend.internal",
+	}
+	if err := createGame(t, addr, WithTLS(tlsConfig)); err != nil {
+		t.Fatalf("CreateGame over mutual TLS: %v", err)
+	}
+
+	tlsConfig.CertFile, tlsConfig.KeyFile = "", ""
+	if err := createGame(t, addr, WithTLS(tlsConfig)); err == nil {
+		t.Error("expected the server to reject a client without a certificate")
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	pki := newTestPKI(t)
+	addr := pki.serve(t, false, requireToken("s3cret"))
+	tlsConfig := TLSConfig{CAFile: pki.path("ca.pem"), ServerName: "backend.internal"}
+
+	if err := createGame(t, addr, WithTLS(tlsConfig), WithToken("s3cret")); err != nil {
+		t.Fatalf("CreateGame with token: %v", err)
+	}
+
+	err := createGame(t, addr, WithTLS(tlsConfig), WithToken("wrong"))
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("wrong token: err = %v, want Unauthenticated", err)
+	}
+
+	// The manager's shared connection must carry the token too.
+	m, err := NewManager(addr, WithTLS(tlsConfig), WithToken("s3cret"))
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	defer m.Close()
+	c, _ := m.Client()
+	if _, err := c.CreateGame(context.Background(), &pb.GameConfig{}); err != nil {
+		t.Errorf("CreateGame through manager: %v", err)
+	}
+}
+
+func TestInvalidSecurityOptions(t *testing.T) {
+	pki := newTestPKI(t)
+
+	tests := map[string][]Option{
+		"token without TLS":       {WithToken("s3cret")},
+		"cert without key":        {WithTLS(TLSConfig{CertFile: pki.path("client.pem")})},
+		"missing CA bundle":       {WithTLS(TLSConfig{CAFile: pki.path("missing.pem")})},
+		"CA bundle without certs": {WithTLS(TLSConfig{CAFile: pki.path("client-key.pem")})},
+	}
+	for name, opts := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := New("localhost:0", opts...); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
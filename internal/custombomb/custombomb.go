@@ -0,0 +1,116 @@
+// Package custombomb holds the limits the backend's ValidateBombConfig puts
+// on custom bombs, so the screens that build them, the mission catalog and
+// the fake backend all refuse the same ones.
+package custombomb
+
+import (
+	"errors"
+	"fmt"
+
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+const (
+	MinTimerSeconds = 5
+	MaxTimerSeconds = 3600
+	MaxStrikes      = 10
+	MaxFaces        = 10
+	MaxRows         = 4
+	MaxColumns      = 5
+	// MaxModules counts the clock, like min_modules does.
+	MaxModules        = 60
+	MaxModulesPerFace = 15
+	MaxBatteries      = 6
+	MaxIndicators     = 5
+	MaxPorts          = 6
+)
+
+// A Problem is one reason the backend would refuse a custom bomb. Field is
+// the CustomBombConfig field at fault, or the backend's name for a group of
+// them.
+type Problem struct {
+	Field  string
+	Reason string
+}
+
+func (p Problem) Error() string {
+	return p.Field + ": " + p.Reason
+}
+
+// PerFace is how many modules a face of rows by columns can hold.
+func PerFace(rows, columns int) int {
+	return min(rows*columns, MaxModulesPerFace)
+}
+
+// Slots is how many modules, the clock included, fit on custom.
+func Slots(custom *pb.CustomBombConfig) int {
+	perFace := custom.GetRows() * custom.GetColumns()
+	if limit := custom.GetMaxModulesPerFace(); limit > 0 {
+		perFace = min(perFace, limit)
+	}
+	return int(custom.GetNumFaces() * perFace)
+}
+
+// Problems lists every reason the backend would refuse custom. Like the
+// backend, it counts the clock among the modules, so min_modules must leave a
+// slot for it.
+func Problems(custom *pb.CustomBombConfig) []Problem {
+	var problems []Problem
+	invalid := func(field, format string, args ...any) {
+		problems = append(problems, Problem{Field: field, Reason: fmt.Sprintf(format, args...)})
+	}
+
+	if t := custom.GetTimerSeconds(); t < MinTimerSeconds || t > MaxTimerSeconds {
+		invalid("timer", "must be between %d and %d seconds", MinTimerSeconds, MaxTimerSeconds)
+	}
+	if s := custom.GetMaxStrikes(); s < 1 || s > MaxStrikes {
+		invalid("max_strikes", "must be between 1 and %d", MaxStrikes)
+	}
+	if f := custom.GetNumFaces(); f < 1 || f > MaxFaces {
+		invalid("num_faces", "must be between 1 and %d", MaxFaces)
+	}
+	if r := custom.GetRows(); r < 1 || r > MaxRows {
+		invalid("rows", "must be between 1 and %d", MaxRows)
+	}
+	if c := custom.GetColumns(); c < 1 || c > MaxColumns {
+		invalid("columns", "must be between 1 and %d", MaxColumns)
+	}
+
+	if custom.GetMinModules() < 1 {
+		invalid("min_modules", "must be at least 1")
+	}
+	if slots := Slots(custom); int(custom.GetMinModules()) > slots {
+		invalid("min_modules", "%d modules, counting the clock, exceed available slots (%d)", custom.GetMinModules(), slots)
+	}
+	if custom.GetMinModules() > MaxModules {
+		invalid("min_modules", "%d modules, counting the clock, exceed %d modules total", custom.GetMinModules(), MaxModules)
+	}
+	if p := custom.GetMaxModulesPerFace(); p < 0 || p > MaxModulesPerFace {
+		invalid("max_modules_per_face", "must be between 0 and %d", MaxModulesPerFace)
+	}
+
+	if custom.GetMinBatteries() < 0 || custom.GetMaxBatteries() > MaxBatteries {
+		invalid("batteries", "must be between 0 and %d", MaxBatteries)
+	}
+	if custom.GetMinBatteries() > custom.GetMaxBatteries() {
+		invalid("batteries", "min_batteries cannot exceed max_batteries")
+	}
+	if custom.GetMaxIndicatorCount() > MaxIndicators {
+		invalid("max_indicator_count", "cannot exceed %d", MaxIndicators)
+	}
+	if custom.GetPortCount() > MaxPorts {
+		invalid("port_count", "cannot exceed %d", MaxPorts)
+	}
+
+	return problems
+}
+
+// Validate joins every problem with custom into one error, or returns nil if
+// the backend would build it.
+func Validate(custom *pb.CustomBombConfig) error {
+	var errs []error
+	for _, problem := range Problems(custom) {
+		errs = append(errs, problem)
+	}
+	return errors.Join(errs...)
+}
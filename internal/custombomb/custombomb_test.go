@@ -0,0 +1,52 @@
+package custombomb
+
+import (
+	"strings"
+	"testing"
+
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+func TestSlots(t *testing.T) {
+	for _, tc := range []struct {
+		faces, rows, columns, perFace int32
+		want                          int
+	}{
+		{faces: 2, rows: 2, columns: 3, want: 12},
+		{faces: 2, rows: 4, columns: 5, want: 40},
+		{faces: 2, rows: 4, columns: 5, perFace: 15, want: 30},
+		{faces: 3, rows: 1, columns: 2, perFace: 15, want: 6},
+	} {
+		custom := &pb.CustomBombConfig{NumFaces: tc.faces, Rows: tc.rows, Columns: tc.columns, MaxModulesPerFace: tc.perFace}
+		if got := Slots(custom); got != tc.want {
+			t.Errorf("Slots(%v) = %d, want %d", custom, got, tc.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	custom := &pb.CustomBombConfig{
+		TimerSeconds:      300,
+		MaxStrikes:        3,
+		NumFaces:          2,
+		Rows:              2,
+		Columns:           3,
+		MinModules:        12,
+		MaxModulesPerFace: int32(PerFace(2, 3)),
+	}
+	if err := Validate(custom); err != nil {
+		t.Fatalf("Validate = %v, want nil", err)
+	}
+
+	custom.NumFaces, custom.Rows, custom.Columns = 5, 4, 5
+	custom.MinModules, custom.MaxModulesPerFace = 61, 20
+	err := Validate(custom)
+	for _, want := range []string{
+		"min_modules: 61 modules, counting the clock, exceed 60 modules total",
+		"max_modules_per_face: must be between 0 and 15",
+	} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate = %v, want %q", err, want)
+		}
+	}
+}
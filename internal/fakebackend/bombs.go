@@ -0,0 +1,274 @@
+package fakebackend
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ZaneH/defuse.party-tui/internal/custombomb"
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+const (
+	defaultTimerSeconds = 300
+	defaultMaxStrikes   = 3
+	defaultRows         = 2
+	defaultColumns      = 3
+
+	needyCountdownSeconds = 45
+	mazeSize              = 6
+)
+
+// defaultModuleTypes is one of every module the TUI knows how to render, so a
+// default game exercises all of them.
+var defaultModuleTypes = []pb.Module_ModuleType{
+	pb.Module_WIRES,
+	pb.Module_BIG_BUTTON,
+	pb.Module_KEYPAD,
+	pb.Module_PASSWORD,
+	pb.Module_MORSE,
+	pb.Module_SIMON,
+	pb.Module_MEMORY,
+	pb.Module_WHOS_ON_FIRST,
+	pb.Module_MAZE,
+	pb.Module_NEEDY_VENT_GAS,
+	pb.Module_NEEDY_KNOB,
+}
+
+var (
+	wireColors   = []pb.Color{pb.Color_RED, pb.Color_BLUE, pb.Color_WHITE, pb.Color_BLACK, pb.Color_YELLOW}
+	simonColors  = []pb.Color{pb.Color_RED, pb.Color_BLUE, pb.Color_GREEN, pb.Color_YELLOW}
+	buttonLabels = []string{"ABORT", "DETONATE", "HOLD", "PRESS"}
+	passwords    = []string{"ABOUT", "AFTER", "AGAIN", "BELOW", "COULD", "EVERY", "FIRST", "FOUND", "GREAT", "HOUSE"}
+	screenWords  = []string{"YES", "FIRST", "DISPLAY", "OKAY", "SAYS", "NOTHING", "BLANK", "NO", "LED", "LEAD", "READ", "RED"}
+	buttonWords  = []string{"READY", "FIRST", "NO", "BLANK", "NOTHING", "YES", "WHAT", "UHHH", "LEFT", "RIGHT", "MIDDLE", "OKAY", "WAIT", "PRESS"}
+	ventQuestion = []string{"VENT GAS?", "DETONATE?"}
+	serialChars  = "ABCDEFGHIJKLMNPQRSTUVWXZ0123456789"
+)
+
+// morsePatterns spell shell, halls, slick, trick, boxes and leaks.
+var morsePatterns = []string{
+	"... .... . .-.. .-..",
+	".... .- .-.. .-.. ...",
+	"... .-.. .. -.-. -.-",
+	"- .-. .. -.-. -.-",
+	"-... --- -..- . ...",
+	".-.. . .- -.- ...",
+}
+
+var morseFrequencies = []float32{
+	3.505, 3.515, 3.522, 3.532, 3.535,
+	3.542, 3.545, 3.552, 3.555, 3.565,
+	3.572, 3.575, 3.582, 3.592, 3.595, 3.600,
+}
+
+// layout describes the bomb to build for a game config.
+type layout struct {
+	timerSeconds int32
+	maxStrikes   int32
+	rows         int32
+	columns      int32
+	modules      []pb.Module_ModuleType
+}
+
+// layoutFor maps a game config onto a bomb layout. Levels and presets all
+// produce the default layout; custom configs keep their timer, strikes, grid
+// and explicit module list so the free play screens can be exercised. Custom
+// configs the real backend would refuse are refused here too.
+func layoutFor(config *pb.GameConfig) (layout, error) {
+	l := layout{
+		timerSeconds: defaultTimerSeconds,
+		maxStrikes:   defaultMaxStrikes,
+		rows:         defaultRows,
+		columns:      defaultColumns,
+		modules:      defaultModuleTypes,
+	}
+
+	custom := config.GetCustom()
+	if custom == nil {
+		return l, nil
+	}
+	if err := custombomb.Validate(custom); err != nil {
+		return layout{}, err
+	}
+
+	l.timerSeconds = custom.GetTimerSeconds()
+	l.maxStrikes = custom.GetMaxStrikes()
+	l.rows = custom.GetRows()
+	l.columns = custom.GetColumns()
+
+	var types []pb.Module_ModuleType
+	for _, spec := range custom.GetModules() {
+		for i := int32(0); i < max(spec.GetCount(), 1); i++ {
+			moduleType := spec.GetType()
+			if possible := spec.GetPossibleTypes(); len(possible) > 0 {
+				moduleType = possible[i%int32(len(possible))]
+			}
+			types = append(types, moduleType)
+		}
+	}
+	if len(types) > 0 {
+		l.modules = types
+	}
+
+	return l, nil
+}
+
+func newBomb(rng *rand.Rand, index int, l layout, now time.Time) *pb.Bomb {
+	bombID := fmt.Sprintf("bomb-%d", index+1)
+	bomb := &pb.Bomb{
+		Id:            bombID,
+		SerialNumber:  serialNumber(rng),
+		TimerDuration: l.timerSeconds,
+		StartedAt:     int32(now.Unix()),
+		MaxStrikes:    l.maxStrikes,
+		Modules:       make(map[string]*pb.Module),
+		Indicators: map[string]*pb.Indicator{
+			"FRK": {Label: "FRK", Lit: rng.Intn(2) == 0},
+		},
+		Batteries: int32(rng.Intn(5)),
+		Ports:     []pb.Port{pb.Port_DVID, pb.Port_RJ45}[:rng.Intn(3)],
+	}
+
+	perFace := l.rows * l.columns
+	types := append([]pb.Module_ModuleType{pb.Module_CLOCK}, l.modules...)
+	for i, moduleType := range types {
+		slot := int32(i)
+		mod := newModule(rng, moduleType, now)
+		mod.Id = fmt.Sprintf("%s-%02d-%s", bombID, i, strings.ToLower(moduleType.String()))
+		mod.Position = &pb.ModulePosition{
+			Face: slot / perFace,
+			Row:  (slot % perFace) / l.columns,
+			Col:  slot % l.columns,
+		}
+		bomb.Modules[mod.GetId()] = mod
+	}
+
+	return bomb
+}
+
+func serialNumber(rng *rand.Rand) string {
+	var sb strings.Builder
+	for i := 0; i < 6; i++ {
+		sb.WriteByte(serialChars[rng.Intn(len(serialChars))])
+	}
+	return sb.String()
+}
+
+func newModule(rng *rand.Rand, moduleType pb.Module_ModuleType, now time.Time) *pb.Module {
+	mod := &pb.Module{Type: moduleType}
+
+	switch moduleType {
+	case pb.Module_WIRES:
+		// Wires sit in some of six 1-based slots.
+		positions := rng.Perm(6)[:3+rng.Intn(4)]
+		sort.Ints(positions)
+		state := &pb.WiresState{}
+		for _, pos := range positions {
+			state.Wires = append(state.Wires, &pb.Wire{
+				WireColor: wireColors[rng.Intn(len(wireColors))],
+				Position:  int32(pos + 1),
+			})
+		}
+		mod.State = &pb.Module_WiresState{WiresState: state}
+	case pb.Module_BIG_BUTTON:
+		mod.State = &pb.Module_BigButtonState{BigButtonState: &pb.BigButtonState{
+			ButtonColor: wireColors[rng.Intn(len(wireColors))],
+			Label:       buttonLabels[rng.Intn(len(buttonLabels))],
+		}}
+	case pb.Module_KEYPAD:
+		var symbols []pb.Symbol
+		for _, i := range rng.Perm(len(pb.Symbol_name))[:4] {
+			symbols = append(symbols, symbolAt(i))
+		}
+		mod.State = &pb.Module_KeypadState{KeypadState: &pb.KeypadState{DisplayedSymbols: symbols}}
+	case pb.Module_PASSWORD:
+		letters := []byte(passwords[rng.Intn(len(passwords))])
+		rng.Shuffle(len(letters), func(i, j int) { letters[i], letters[j] = letters[j], letters[i] })
+		mod.State = &pb.Module_PasswordState{PasswordState: &pb.PasswordState{Letters: string(letters)}}
+	case pb.Module_MORSE:
+		mod.State = &pb.Module_MorseState{MorseState: &pb.MorseState{
+			DisplayedPattern:   morsePatterns[rng.Intn(len(morsePatterns))],
+			DisplayedFrequency: morseFrequencies[0],
+		}}
+	case pb.Module_SIMON:
+		sequence := make([]pb.Color, 3+rng.Intn(3))
+		for i := range sequence {
+			sequence[i] = simonColors[rng.Intn(len(simonColors))]
+		}
+		mod.State = &pb.Module_SimonState{SimonState: &pb.SimonState{CurrentSequence: sequence}}
+	case pb.Module_MEMORY:
+		mod.State = &pb.Module_MemoryState{MemoryState: newMemoryState(rng, 1)}
+	case pb.Module_WHOS_ON_FIRST:
+		mod.State = &pb.Module_WhosOnFirstState{WhosOnFirstState: newWhosOnFirstState(rng, 1)}
+	case pb.Module_MAZE:
+		cells := rng.Perm(mazeSize * mazeSize)
+		point := func(cell int) *pb.Point2D {
+			return &pb.Point2D{X: int64(cell % mazeSize), Y: int64(cell / mazeSize)}
+		}
+		mod.State = &pb.Module_MazeState{MazeState: &pb.MazeState{
+			Marker_1:       point(cells[0]),
+			Marker_2:       point(cells[1]),
+			PlayerPosition: point(cells[2]),
+			GoalPosition:   point(cells[3]),
+		}}
+	case pb.Module_NEEDY_VENT_GAS:
+		mod.State = &pb.Module_NeedyVentGasState{NeedyVentGasState: &pb.NeedyVentGasState{
+			DisplayedQuestion:  ventQuestion[rng.Intn(len(ventQuestion))],
+			CountdownStartedAt: now.Unix(),
+			CountdownDuration:  needyCountdownSeconds,
+		}}
+	case pb.Module_NEEDY_KNOB:
+		mod.State = &pb.Module_NeedyKnobState{NeedyKnobState: newKnobState(rng, now)}
+	}
+
+	return mod
+}
+
+func newMemoryState(rng *rand.Rand, stage int32) *pb.MemoryState {
+	state := &pb.MemoryState{
+		ScreenNumber: int32(rng.Intn(4) + 1),
+		Stage:        stage,
+	}
+	for _, n := range rng.Perm(4) {
+		state.DisplayedNumbers = append(state.DisplayedNumbers, int32(n+1))
+	}
+	return state
+}
+
+func newWhosOnFirstState(rng *rand.Rand, stage int32) *pb.WhosOnFirstState {
+	state := &pb.WhosOnFirstState{
+		ScreenWord: screenWords[rng.Intn(len(screenWords))],
+		Stage:      stage,
+	}
+	for _, i := range rng.Perm(len(buttonWords))[:6] {
+		state.ButtonWords = append(state.ButtonWords, buttonWords[i])
+	}
+	return state
+}
+
+func newKnobState(rng *rand.Rand, now time.Time) *pb.NeedyKnobState {
+	state := &pb.NeedyKnobState{
+		DialDirection:      pb.CardinalDirection(rng.Intn(4)),
+		CountdownStartedAt: now.Unix(),
+		CountdownDuration:  needyCountdownSeconds,
+	}
+	for i := 0; i < 6; i++ {
+		state.DisplayedPatternFirstRow = append(state.DisplayedPatternFirstRow, rng.Intn(2) == 0)
+		state.DisplayedPatternSecondRow = append(state.DisplayedPatternSecondRow, rng.Intn(2) == 0)
+	}
+	return state
+}
+
+// symbolAt returns the i-th defined symbol. The enum has gaps, so indexing
+// the value range directly would produce unknown symbols.
+func symbolAt(i int) pb.Symbol {
+	var values []int
+	for value := range pb.Symbol_name {
+		values = append(values, int(value))
+	}
+	sort.Ints(values)
+	return pb.Symbol(values[i])
+}
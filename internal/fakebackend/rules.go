@@ -0,0 +1,165 @@
+package fakebackend
+
+import (
+	"time"
+
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+// applyInput is the built-in behaviour used when no outcome is scripted. The
+// rules are deliberately simple rather than faithful to the manual: a module
+// is solved by its committing action (cutting a wire, transmitting,
+// submitting, finishing the last stage) and only walking out of the maze
+// earns a strike. Module state is still updated the way the real backend
+// would, so every view can be exercised.
+func applyInput(sess *session, mod *pb.Module, input *pb.PlayerInput, result *pb.PlayerInputResult, now time.Time) Outcome {
+	progress := sess.progress[mod.GetId()]
+
+	switch in := input.GetInput().(type) {
+	case *pb.PlayerInput_WiresInput:
+		for _, wire := range mod.GetWiresState().GetWires() {
+			if wire.GetPosition() == in.WiresInput.GetWirePosition() {
+				wire.IsCut = true
+				return OutcomeSolve
+			}
+		}
+
+	case *pb.PlayerInput_BigButtonInput:
+		switch in.BigButtonInput.GetPressType() {
+		case pb.PressType_HOLD:
+			result.Result = &pb.PlayerInputResult_BigButtonInputResult{BigButtonInputResult: &pb.BigButtonInputResult{
+				StripColor: wireColors[sess.rng.Intn(len(wireColors))],
+			}}
+		default:
+			return OutcomeSolve
+		}
+
+	case *pb.PlayerInput_KeypadInput:
+		state := mod.GetKeypadState()
+		symbol := in.KeypadInput.GetSymbol()
+		if !containsSymbol(state.GetActivatedSymbols(), symbol) && containsSymbol(state.GetDisplayedSymbols(), symbol) {
+			state.ActivatedSymbols = append(state.ActivatedSymbols, symbol)
+		}
+		result.Result = &pb.PlayerInputResult_KeypadInputResult{KeypadInputResult: &pb.KeypadInputResult{KeypadState: state}}
+		if len(state.GetActivatedSymbols()) == len(state.GetDisplayedSymbols()) {
+			return OutcomeSolve
+		}
+
+	case *pb.PlayerInput_PasswordInput:
+		state := mod.GetPasswordState()
+		if in.PasswordInput.GetSubmit() != nil {
+			return OutcomeSolve
+		}
+		change := in.PasswordInput.GetLetterChange()
+		letters := []byte(state.GetLetters())
+		if i := int(change.GetLetterIndex()); i >= 0 && i < len(letters) {
+			letters[i] = rotateLetter(letters[i], change.GetDirection())
+			state.Letters = string(letters)
+		}
+		result.Result = &pb.PlayerInputResult_PasswordInputResult{PasswordInputResult: &pb.PasswordInputResult{PasswordState: state}}
+
+	case *pb.PlayerInput_MorseInput:
+		state := mod.GetMorseState()
+		if in.MorseInput.GetTx() != nil {
+			return OutcomeSolve
+		}
+		index := state.GetSelectedFrequencyIndex()
+		if in.MorseInput.GetFrequencyChange().GetDirection() == pb.IncrementDecrement_INCREMENT {
+			index = min(index+1, int32(len(morseFrequencies)-1))
+		} else {
+			index = max(index-1, 0)
+		}
+		state.SelectedFrequencyIndex = index
+		state.DisplayedFrequency = morseFrequencies[index]
+		result.Result = &pb.PlayerInputResult_MorseInputResult{MorseInputResult: &pb.MorseInputResult{MorseState: state}}
+
+	case *pb.PlayerInput_SimonInput:
+		state := mod.GetSimonState()
+		progress.presses++
+		if progress.presses < len(state.GetCurrentSequence()) {
+			result.Result = &pb.PlayerInputResult_SimonInputResult{SimonInputResult: &pb.SimonInputResult{}}
+			return OutcomeNone
+		}
+		if len(state.GetCurrentSequence()) == len(progress.simonSequence) {
+			return OutcomeSolve
+		}
+		progress.presses = 0
+		state.CurrentSequence = progress.simonSequence[:len(state.GetCurrentSequence())+1]
+		result.Result = &pb.PlayerInputResult_SimonInputResult{SimonInputResult: &pb.SimonInputResult{
+			HasFinishedSeq:  true,
+			DisplaySequence: state.GetCurrentSequence(),
+		}}
+
+	case *pb.PlayerInput_MemoryInput:
+		stage := mod.GetMemoryState().GetStage()
+		if stage >= 5 {
+			return OutcomeSolve
+		}
+		state := newMemoryState(sess.rng, stage+1)
+		mod.State = &pb.Module_MemoryState{MemoryState: state}
+		result.Result = &pb.PlayerInputResult_MemoryInputResult{MemoryInputResult: &pb.MemoryInputResult{MemoryState: state}}
+
+	case *pb.PlayerInput_WhosOnFirstInput:
+		stage := mod.GetWhosOnFirstState().GetStage()
+		if stage >= 3 {
+			return OutcomeSolve
+		}
+		state := newWhosOnFirstState(sess.rng, stage+1)
+		mod.State = &pb.Module_WhosOnFirstState{WhosOnFirstState: state}
+		result.Result = &pb.PlayerInputResult_WhosOnFirstInputResult{WhosOnFirstInputResult: &pb.WhosOnFirstInputResult{WhosOnFirstState: state}}
+
+	case *pb.PlayerInput_MazeInput:
+		state := mod.GetMazeState()
+		x, y := state.GetPlayerPosition().GetX(), state.GetPlayerPosition().GetY()
+		switch in.MazeInput.GetDirection() {
+		case pb.CardinalDirection_NORTH:
+			y--
+		case pb.CardinalDirection_SOUTH:
+			y++
+		case pb.CardinalDirection_EAST:
+			x++
+		case pb.CardinalDirection_WEST:
+			x--
+		}
+		result.Result = &pb.PlayerInputResult_MazeInputResult{MazeInputResult: &pb.MazeInputResult{MazeState: state}}
+		if x < 0 || y < 0 || x >= mazeSize || y >= mazeSize {
+			return OutcomeStrike
+		}
+		state.PlayerPosition = &pb.Point2D{X: x, Y: y}
+		if x == state.GetGoalPosition().GetX() && y == state.GetGoalPosition().GetY() {
+			return OutcomeSolve
+		}
+
+	case *pb.PlayerInput_NeedyVentGasInput:
+		state := mod.GetNeedyVentGasState()
+		state.CountdownStartedAt = now.Unix()
+		state.DisplayedQuestion = ventQuestion[sess.rng.Intn(len(ventQuestion))]
+		result.Result = &pb.PlayerInputResult_NeedyVentGasInputResult{NeedyVentGasInputResult: &pb.NeedyVentGasInputResult{NeedyVentGasState: state}}
+
+	case *pb.PlayerInput_NeedyKnobInput:
+		state := newKnobState(sess.rng, now)
+		mod.State = &pb.Module_NeedyKnobState{NeedyKnobState: state}
+		result.Result = &pb.PlayerInputResult_NeedyKnobInputResult{NeedyKnobInputResult: &pb.NeedyKnobInputResult{NeedyKnobState: state}}
+	}
+
+	return OutcomeNone
+}
+
+func containsSymbol(symbols []pb.Symbol, symbol pb.Symbol) bool {
+	for _, s := range symbols {
+		if s == symbol {
+			return true
+		}
+	}
+	return false
+}
+
+func rotateLetter(letter byte, direction pb.IncrementDecrement) byte {
+	offset := int(letter - 'A')
+	if direction == pb.IncrementDecrement_INCREMENT {
+		offset = (offset + 1) % 26
+	} else {
+		offset = (offset + 25) % 26
+	}
+	return byte('A' + offset)
+}
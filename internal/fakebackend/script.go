@@ -0,0 +1,120 @@
+package fakebackend
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+type Outcome int
+
+const (
+	// OutcomeNone accepts the input without a strike or a solve.
+	OutcomeNone Outcome = iota
+	OutcomeStrike
+	OutcomeSolve
+	// OutcomeExplode strikes the bomb out immediately.
+	OutcomeExplode
+)
+
+var outcomeNames = map[string]Outcome{
+	"none":    OutcomeNone,
+	"strike":  OutcomeStrike,
+	"solve":   OutcomeSolve,
+	"explode": OutcomeExplode,
+}
+
+func (o Outcome) String() string {
+	for name, outcome := range outcomeNames {
+		if outcome == o {
+			return name
+		}
+	}
+	return fmt.Sprintf("Outcome(%d)", int(o))
+}
+
+// Script queues outcomes for upcoming inputs, either for a specific module ID
+// or for every module of a type. Module queues take precedence over type
+// queues. Once a queue is drained the built-in rules apply again.
+type Script struct {
+	mu     sync.Mutex
+	byID   map[string][]Outcome
+	byType map[pb.Module_ModuleType][]Outcome
+}
+
+func NewScript() *Script {
+	return &Script{
+		byID:   make(map[string][]Outcome),
+		byType: make(map[pb.Module_ModuleType][]Outcome),
+	}
+}
+
+// Module queues outcomes for the next inputs sent to moduleID.
+func (s *Script) Module(moduleID string, outcomes ...Outcome) *Script {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.byID[moduleID] = append(s.byID[moduleID], outcomes...)
+	return s
+}
+
+// Type queues outcomes for the next inputs sent to any module of moduleType.
+func (s *Script) Type(moduleType pb.Module_ModuleType, outcomes ...Outcome) *Script {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.byType[moduleType] = append(s.byType[moduleType], outcomes...)
+	return s
+}
+
+func (s *Script) next(mod *pb.Module) (Outcome, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if queue := s.byID[mod.GetId()]; len(queue) > 0 {
+		s.byID[mod.GetId()] = queue[1:]
+		return queue[0], true
+	}
+	if queue := s.byType[mod.GetType()]; len(queue) > 0 {
+		s.byType[mod.GetType()] = queue[1:]
+		return queue[0], true
+	}
+	return OutcomeNone, false
+}
+
+// ParseScript reads a script such as "WIRES=strike,solve;bomb-1-03-keypad=explode".
+// Keys that name a module type apply to every module of that type; anything
+// else is treated as a module ID.
+func ParseScript(spec string) (*Script, error) {
+	script := NewScript()
+
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		key, list, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid script entry %q: expected KEY=outcome,...", entry)
+		}
+		key = strings.TrimSpace(key)
+
+		var outcomes []Outcome
+		for _, name := range strings.Split(list, ",") {
+			outcome, ok := outcomeNames[strings.ToLower(strings.TrimSpace(name))]
+			if !ok {
+				return nil, fmt.Errorf("invalid outcome %q for %s", name, key)
+			}
+			outcomes = append(outcomes, outcome)
+		}
+
+		if value, ok := pb.Module_ModuleType_value[strings.ToUpper(key)]; ok {
+			script.Type(pb.Module_ModuleType(value), outcomes...)
+		} else {
+			script.Module(key, outcomes...)
+		}
+	}
+
+	return script, nil
+}
@@ -0,0 +1,239 @@
+// Package fakebackend is an in-memory implementation of the defuse.party
+// GameService. It generates deterministic bombs from a seed and resolves
+// inputs with simple built-in rules that can be overridden per module, so the
+// TUI can be developed and tested without the real backend.
+package fakebackend
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+type Server struct {
+	pb.UnimplementedGameServiceServer
+
+	seed int64
+	now  func() time.Time
+
+	mu       sync.Mutex
+	sessions map[string]*session
+	nextID   int
+	script   *Script
+}
+
+type Option func(*Server)
+
+// WithClock replaces time.Now, which is used for bomb start times and needy
+// countdowns.
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// WithScript preloads outcomes for upcoming inputs.
+func WithScript(script *Script) Option {
+	return func(s *Server) {
+		s.script = script
+	}
+}
+
+// New creates a server whose bombs are derived from seed. Games created with
+// a GameConfig.Seed use that instead, so the same seed always yields the same
+// bomb regardless of how many games came before it.
+func New(seed int64, opts ...Option) *Server {
+	s := &Server{
+		seed:     seed,
+		now:      time.Now,
+		sessions: make(map[string]*session),
+		script:   NewScript(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Script returns the outcome script shared by all sessions. It may be
+// modified while the server is running.
+func (s *Server) Script() *Script {
+	return s.script
+}
+
+type session struct {
+	rng      *rand.Rand
+	bombs    []*pb.Bomb
+	progress map[string]*moduleProgress
+}
+
+// moduleProgress is the hidden, server-side part of a module's state.
+type moduleProgress struct {
+	presses       int
+	simonSequence []pb.Color
+}
+
+func (s *Server) CreateGame(ctx context.Context, req *pb.CreateGameRequest) (*pb.CreateGameResponse, error) {
+	l, err := layoutFor(req.GetConfig())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid bomb config: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seed := s.seed
+	if configSeed := req.GetConfig().GetSeed(); configSeed != "" {
+		h := fnv.New64a()
+		h.Write([]byte(configSeed))
+		seed = int64(h.Sum64())
+	}
+
+	sess := &session{
+		rng:      rand.New(rand.NewSource(seed)),
+		progress: make(map[string]*moduleProgress),
+	}
+	bomb := newBomb(sess.rng, 0, l, s.now())
+	for id, mod := range bomb.GetModules() {
+		progress := &moduleProgress{}
+		if simon := mod.GetSimonState(); simon != nil {
+			progress.simonSequence = simon.GetCurrentSequence()
+			simon.CurrentSequence = progress.simonSequence[:1]
+		}
+		sess.progress[id] = progress
+	}
+	sess.bombs = []*pb.Bomb{bomb}
+
+	s.nextID++
+	sessionID := fmt.Sprintf("fake-session-%d", s.nextID)
+	s.sessions[sessionID] = sess
+
+	return &pb.CreateGameResponse{SessionId: sessionID}, nil
+}
+
+func (s *Server) GetBombs(ctx context.Context, req *pb.GetBombsRequest) (*pb.GetBombsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sess, ok := s.sessions[req.GetSessionId()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "session %q not found", req.GetSessionId())
+	}
+
+	now := s.now()
+	for _, bomb := range sess.bombs {
+		expireNeedy(sess, bomb, now)
+	}
+
+	resp := &pb.GetBombsResponse{}
+	for _, bomb := range sess.bombs {
+		resp.Bombs = append(resp.Bombs, proto.Clone(bomb).(*pb.Bomb))
+	}
+	return resp, nil
+}
+
+func (s *Server) SendInput(ctx context.Context, input *pb.PlayerInput) (*pb.PlayerInputResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sess, ok := s.sessions[input.GetSessionId()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "session %q not found", input.GetSessionId())
+	}
+
+	var bomb *pb.Bomb
+	for _, b := range sess.bombs {
+		if b.GetId() == input.GetBombId() {
+			bomb = b
+		}
+	}
+	if bomb == nil {
+		return nil, status.Errorf(codes.NotFound, "bomb %q not found", input.GetBombId())
+	}
+
+	mod, ok := bomb.GetModules()[input.GetModuleId()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "module %q not found", input.GetModuleId())
+	}
+
+	if exploded(bomb) {
+		return nil, status.Error(codes.FailedPrecondition, "bomb has already exploded")
+	}
+
+	now := s.now()
+	expireNeedy(sess, bomb, now)
+
+	result := &pb.PlayerInputResult{ModuleId: mod.GetId()}
+	outcome, scripted := s.script.next(mod)
+	if !scripted {
+		outcome = applyInput(sess, mod, input, result, now)
+	}
+
+	switch outcome {
+	case OutcomeStrike:
+		result.Strike = true
+		bomb.StrikeCount++
+	case OutcomeSolve:
+		if !isNeedy(mod.GetType()) {
+			mod.Solved = true
+			result.Solved = true
+		}
+	case OutcomeExplode:
+		result.Strike = true
+		bomb.StrikeCount = bomb.GetMaxStrikes()
+	}
+
+	result.BombStatus = &pb.BombStatus{
+		StrikeCount: bomb.GetStrikeCount(),
+		MaxStrikes:  bomb.GetMaxStrikes(),
+		Exploded:    exploded(bomb),
+	}
+	return proto.Clone(result).(*pb.PlayerInputResult), nil
+}
+
+func exploded(bomb *pb.Bomb) bool {
+	return bomb.GetStrikeCount() >= bomb.GetMaxStrikes()
+}
+
+func isNeedy(moduleType pb.Module_ModuleType) bool {
+	return moduleType == pb.Module_NEEDY_VENT_GAS || moduleType == pb.Module_NEEDY_KNOB
+}
+
+// expireNeedy gives a strike for every needy countdown that ran out since the
+// last request and restarts it.
+func expireNeedy(sess *session, bomb *pb.Bomb, now time.Time) {
+	for _, mod := range bomb.GetModules() {
+		var startedAt *int64
+		var duration int32
+		switch {
+		case mod.GetNeedyVentGasState() != nil:
+			startedAt = &mod.GetNeedyVentGasState().CountdownStartedAt
+			duration = mod.GetNeedyVentGasState().GetCountdownDuration()
+		case mod.GetNeedyKnobState() != nil:
+			startedAt = &mod.GetNeedyKnobState().CountdownStartedAt
+			duration = mod.GetNeedyKnobState().GetCountdownDuration()
+		default:
+			continue
+		}
+
+		if *startedAt == 0 || exploded(bomb) {
+			continue
+		}
+		if now.Sub(time.Unix(*startedAt, 0)) >= time.Duration(duration)*time.Second {
+			bomb.StrikeCount++
+			*startedAt = now.Unix()
+			if knob := mod.GetNeedyKnobState(); knob != nil {
+				knob.DialDirection = pb.CardinalDirection(sess.rng.Intn(4))
+			}
+		}
+	}
+}
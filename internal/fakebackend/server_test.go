@@ -0,0 +1,207 @@
+package fakebackend
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ZaneH/defuse.party-tui/internal/client"
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+var fixedNow = time.Unix(1700000000, 0)
+
+func newTestServer(seed int64) *Server {
+	return New(seed, WithClock(func() time.Time { return fixedNow }))
+}
+
+func createBomb(t *testing.T, s *Server, config *pb.GameConfig) (string, *pb.Bomb) {
+	t.Helper()
+	ctx := context.Background()
+
+	created, err := s.CreateGame(ctx, &pb.CreateGameRequest{Config: config})
+	if err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+	resp, err := s.GetBombs(ctx, &pb.GetBombsRequest{SessionId: created.GetSessionId()})
+	if err != nil {
+		t.Fatalf("GetBombs: %v", err)
+	}
+	return created.GetSessionId(), resp.GetBombs()[0]
+}
+
+func moduleOfType(bomb *pb.Bomb, moduleType pb.Module_ModuleType) *pb.Module {
+	for _, mod := range bomb.GetModules() {
+		if mod.GetType() == moduleType {
+			return mod
+		}
+	}
+	return nil
+}
+
+func TestBombsAreDeterministic(t *testing.T) {
+	_, a := createBomb(t, newTestServer(42), nil)
+	_, b := createBomb(t, newTestServer(42), nil)
+	if !proto.Equal(a, b) {
+		t.Fatal("same seed produced different bombs")
+	}
+
+	_, c := createBomb(t, newTestServer(43), nil)
+	if proto.Equal(a, c) {
+		t.Fatal("different seeds produced identical bombs")
+	}
+
+	seeded := &pb.GameConfig{Seed: "2026-10-16"}
+	_, d := createBomb(t, newTestServer(1), seeded)
+	_, e := createBomb(t, newTestServer(2), seeded)
+	if !proto.Equal(d, e) {
+		t.Fatal("GameConfig.Seed did not override the server seed")
+	}
+}
+
+func TestDefaultBombCoversEveryModuleType(t *testing.T) {
+	_, bomb := createBomb(t, newTestServer(1), nil)
+
+	for _, moduleType := range append(defaultModuleTypes, pb.Module_CLOCK) {
+		if moduleOfType(bomb, moduleType) == nil {
+			t.Errorf("bomb has no %s module", moduleType)
+		}
+	}
+}
+
+func TestCustomBombs(t *testing.T) {
+	custom := &pb.CustomBombConfig{
+		TimerSeconds: 120,
+		MaxStrikes:   2,
+		NumFaces:     1,
+		Rows:         1,
+		Columns:      3,
+		MinModules:   3,
+		Modules:      []*pb.ModuleSpec{{Type: pb.Module_WIRES, Count: 2}},
+	}
+	_, bomb := createBomb(t, newTestServer(1), &pb.GameConfig{ConfigType: &pb.GameConfig_Custom{Custom: custom}})
+	if len(bomb.GetModules()) != 3 || bomb.GetTimerDuration() != 120 {
+		t.Errorf("bomb has %d modules and a %ds timer, want the clock, 2 wires and 120s", len(bomb.GetModules()), bomb.GetTimerDuration())
+	}
+
+	// Grids, limits and module counts the real backend refuses.
+	invalid := proto.Clone(custom).(*pb.CustomBombConfig)
+	invalid.Rows, invalid.Columns, invalid.MinModules = 0, 6, 4
+	_, err := newTestServer(1).CreateGame(context.Background(), &pb.CreateGameRequest{
+		Config: &pb.GameConfig{ConfigType: &pb.GameConfig_Custom{Custom: invalid}},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("CreateGame err = %v, want InvalidArgument", err)
+	}
+	for _, want := range []string{"rows: must be between 1 and 4", "columns: must be between 1 and 5", "min_modules: 4 modules, counting the clock, exceed available slots (0)"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error is missing %q: %v", want, err)
+		}
+	}
+}
+
+func TestScriptedOutcomes(t *testing.T) {
+	s := newTestServer(1)
+	s.Script().Type(pb.Module_WIRES, OutcomeStrike).Type(pb.Module_MAZE, OutcomeExplode)
+	sessionID, bomb := createBomb(t, s, nil)
+
+	send := func(mod *pb.Module, input *pb.PlayerInput) *pb.PlayerInputResult {
+		t.Helper()
+		input.SessionId = sessionID
+		input.BombId = bomb.GetId()
+		input.ModuleId = mod.GetId()
+		result, err := s.SendInput(context.Background(), input)
+		if err != nil {
+			t.Fatalf("SendInput: %v", err)
+		}
+		return result
+	}
+
+	wires := moduleOfType(bomb, pb.Module_WIRES)
+	position := wires.GetWiresState().GetWires()[0].GetPosition()
+	cut := &pb.PlayerInput{Input: &pb.PlayerInput_WiresInput{WiresInput: &pb.WiresInput{WirePosition: position}}}
+
+	result := send(wires, cut)
+	if !result.GetStrike() || result.GetSolved() || result.GetBombStatus().GetStrikeCount() != 1 {
+		t.Fatalf("scripted strike: got %v", result)
+	}
+
+	result = send(wires, cut)
+	if result.GetStrike() || !result.GetSolved() {
+		t.Fatalf("built-in rule after script drained: got %v", result)
+	}
+
+	maze := moduleOfType(bomb, pb.Module_MAZE)
+	result = send(maze, &pb.PlayerInput{Input: &pb.PlayerInput_MazeInput{MazeInput: &pb.MazeInput{}}})
+	if !result.GetBombStatus().GetExploded() {
+		t.Fatalf("scripted explosion: got %v", result)
+	}
+
+	if _, err := s.SendInput(context.Background(), &pb.PlayerInput{
+		SessionId: sessionID,
+		BombId:    bomb.GetId(),
+		ModuleId:  wires.GetId(),
+		Input:     cut.GetInput(),
+	}); err == nil {
+		t.Fatal("expected input after explosion to fail")
+	}
+}
+
+func TestParseScript(t *testing.T) {
+	script, err := ParseScript("wires=strike,solve; bomb-1-02-keypad=explode")
+	if err != nil {
+		t.Fatalf("ParseScript: %v", err)
+	}
+
+	wires := &pb.Module{Id: "w", Type: pb.Module_WIRES}
+	keypad := &pb.Module{Id: "bomb-1-02-keypad", Type: pb.Module_KEYPAD}
+	for _, want := range []Outcome{OutcomeStrike, OutcomeSolve} {
+		if got, ok := script.next(wires); !ok || got != want {
+			t.Fatalf("wires: got %v, %v; want %v", got, ok, want)
+		}
+	}
+	if got, ok := script.next(keypad); !ok || got != OutcomeExplode {
+		t.Fatalf("keypad: got %v, %v", got, ok)
+	}
+	if _, ok := script.next(wires); ok {
+		t.Fatal("expected wires queue to be drained")
+	}
+
+	if _, err := ParseScript("WIRES=maybe"); err == nil {
+		t.Fatal("expected an error for an unknown outcome")
+	}
+}
+
+func TestServesGRPCClient(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	gs := grpc.NewServer()
+	pb.RegisterGameServiceServer(gs, newTestServer(1))
+	go gs.Serve(lis)
+	defer gs.Stop()
+
+	c, err := client.New(lis.Addr().String())
+	if err != nil {
+		t.Fatalf("client.New: %v", err)
+	}
+	defer c.Close()
+
+	ctx := context.Background()
+	sessionID, err := c.CreateGame(ctx, &pb.GameConfig{})
+	if err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+	bombs, err := c.GetBombs(ctx, sessionID)
+	if err != nil || len(bombs) != 1 {
+		t.Fatalf("GetBombs: %v, %d bombs", err, len(bombs))
+	}
+}
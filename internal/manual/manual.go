@@ -0,0 +1,110 @@
+// Package manual embeds the bomb defusal manual so it can be read over a
+// plain SSH session. There is one page per module type the client can play.
+package manual
+
+import (
+	"embed"
+	"strings"
+
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+//go:embed pages/*.txt
+var pageFiles embed.FS
+
+// Page is the manual section for one module type.
+type Page struct {
+	Type  pb.Module_ModuleType
+	Title string
+	Lines []string
+}
+
+// Match is a line of the manual containing a search query.
+type Match struct {
+	Page int
+	Line int
+}
+
+// contents lists the pages in manual order: regular modules roughly by
+// difficulty, then needy modules.
+var contents = []struct {
+	Type  pb.Module_ModuleType
+	Title string
+}{
+	{pb.Module_WIRES, "Wires"},
+	{pb.Module_BIG_BUTTON, "Big Button"},
+	{pb.Module_KEYPAD, "Keypad"},
+	{pb.Module_SIMON, "Simon"},
+	{pb.Module_WHOS_ON_FIRST, "Who's on First"},
+	{pb.Module_MEMORY, "Memory"},
+	{pb.Module_MORSE, "Morse Code"},
+	{pb.Module_MAZE, "Maze"},
+	{pb.Module_PASSWORD, "Password"},
+	{pb.Module_NEEDY_VENT_GAS, "Vent Gas (Needy)"},
+	{pb.Module_NEEDY_KNOB, "Knob (Needy)"},
+}
+
+var pages = loadPages()
+
+func loadPages() []Page {
+	loaded := make([]Page, len(contents))
+	for i, entry := range contents {
+		// A missing page is a build mistake, not something to recover from.
+		data, err := pageFiles.ReadFile("pages/" + strings.ToLower(entry.Type.String()) + ".txt")
+		if err != nil {
+			panic(err)
+		}
+		loaded[i] = Page{
+			Type:  entry.Type,
+			Title: entry.Title,
+			Lines: strings.Split(strings.TrimRight(string(data), "\n"), "\n"),
+		}
+	}
+	return loaded
+}
+
+// Pages returns every page in manual order.
+func Pages() []Page {
+	return pages
+}
+
+// Index returns the position of t's page in Pages.
+func Index(t pb.Module_ModuleType) (int, bool) {
+	for i, page := range pages {
+		if page.Type == t {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Lookup returns the page for module type t.
+func Lookup(t pb.Module_ModuleType) (Page, bool) {
+	i, ok := Index(t)
+	if !ok {
+		return Page{}, false
+	}
+	return pages[i], true
+}
+
+// Search finds every line containing query, ignoring case. Page titles are
+// searched too and match as line -1.
+func Search(query string) []Match {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+
+	var matches []Match
+	for i, page := range pages {
+		if strings.Contains(strings.ToLower(page.Title), query) {
+			matches = append(matches, Match{Page: i, Line: -1})
+		}
+		for j, line := range page.Lines {
+			if strings.Contains(strings.ToLower(line), query) {
+				matches = append(matches, Match{Page: i, Line: j})
+			}
+		}
+	}
+	return matches
+}
@@ -0,0 +1,48 @@
+package manual
+
+import (
+	"testing"
+
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+// Every module type the client can play has a page.
+func TestEveryModuleHasAPage(t *testing.T) {
+	for v, name := range pb.Module_ModuleType_name {
+		mt := pb.Module_ModuleType(v)
+		if mt == pb.Module_UNKNOWN || mt == pb.Module_CLOCK {
+			continue
+		}
+		page, ok := Lookup(mt)
+		if !ok {
+			t.Errorf("no manual page for %s", name)
+			continue
+		}
+		if page.Title == "" || len(page.Lines) < 5 {
+			t.Errorf("page for %s looks empty: %+v", name, page)
+		}
+	}
+	if _, ok := Lookup(pb.Module_CLOCK); ok {
+		t.Error("the clock has a manual page")
+	}
+}
+
+func TestSearch(t *testing.T) {
+	matches := Search("  VeNt GaS ")
+	if len(matches) < 2 {
+		t.Fatalf("Search found %v, want the title and the answer line", matches)
+	}
+	page := Pages()[matches[0].Page]
+	if page.Type != pb.Module_NEEDY_VENT_GAS || matches[0].Line != -1 {
+		t.Errorf("first match = %+v on %s, want the Venting Gas title", matches[0], page.Title)
+	}
+
+	for _, m := range Search("serial number") {
+		if Pages()[m.Page].Type == pb.Module_MAZE {
+			t.Errorf("matched unrelated line %q", Pages()[m.Page].Lines[m.Line])
+		}
+	}
+	if matches := Search(""); matches != nil {
+		t.Errorf("empty search found %v", matches)
+	}
+}
@@ -0,0 +1,125 @@
+package profile
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var dailyBucket = []byte("daily")
+
+// DailyEntry is a player's ranked attempt at one day's Daily Bomb. It is
+// created when the attempt starts, so leaving mid-game still uses it up.
+type DailyEntry struct {
+	PlayerID string        `json:"player_id"`
+	Nickname string        `json:"nickname"`
+	Finished bool          `json:"finished"`
+	Outcome  string        `json:"outcome,omitempty"`
+	Duration time.Duration `json:"duration,omitempty"`
+	Strikes  int           `json:"strikes,omitempty"`
+	PlayedAt time.Time     `json:"played_at"`
+}
+
+// Defused reports whether the attempt counts towards the rankings.
+func (e DailyEntry) Defused() bool {
+	return e.Finished && e.Outcome == "defused"
+}
+
+// ClaimDaily starts the player's ranked attempt for date. It reports false,
+// leaving the existing entry alone, if they have already had one.
+func (s *Store) ClaimDaily(date, id, nickname string) (bool, error) {
+	claimed := false
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		day, err := tx.Bucket(dailyBucket).CreateBucketIfNotExists([]byte(date))
+		if err != nil {
+			return err
+		}
+		if day.Get([]byte(id)) != nil {
+			return nil
+		}
+		claimed = true
+		return putDailyEntry(day, DailyEntry{PlayerID: id, Nickname: nickname, PlayedAt: time.Now()})
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to claim daily attempt: %w", err)
+	}
+	return claimed, nil
+}
+
+// FinishDaily records how the player's ranked attempt for date went. Only the
+// first result is kept.
+func (s *Store) FinishDaily(date, id, outcome string, duration time.Duration, strikes int) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		day := tx.Bucket(dailyBucket).Bucket([]byte(date))
+		if day == nil {
+			return fmt.Errorf("no daily attempt for %s on %s", id, date)
+		}
+		data := day.Get([]byte(id))
+		if data == nil {
+			return fmt.Errorf("no daily attempt for %s on %s", id, date)
+		}
+		var entry DailyEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return fmt.Errorf("failed to decode daily entry: %w", err)
+		}
+		if entry.Finished {
+			return nil
+		}
+		entry.Finished = true
+		entry.Outcome = outcome
+		entry.Duration = duration
+		entry.Strikes = strikes
+		return putDailyEntry(day, entry)
+	})
+}
+
+// DailyLeaderboard returns every attempt for date, best first: defused bombs
+// by time then strikes, followed by the rest in the order they were played.
+func (s *Store) DailyLeaderboard(date string) ([]DailyEntry, error) {
+	var entries []DailyEntry
+	err := s.db.View(func(tx *bolt.Tx) error {
+		day := tx.Bucket(dailyBucket).Bucket([]byte(date))
+		if day == nil {
+			return nil
+		}
+		return day.ForEach(func(_, data []byte) error {
+			var entry DailyEntry
+			if err := json.Unmarshal(data, &entry); err != nil {
+				return fmt.Errorf("failed to decode daily entry: %w", err)
+			}
+			entries = append(entries, entry)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Defused() != b.Defused() {
+			return a.Defused()
+		}
+		if a.Defused() {
+			if a.Duration != b.Duration {
+				return a.Duration < b.Duration
+			}
+			if a.Strikes != b.Strikes {
+				return a.Strikes < b.Strikes
+			}
+		}
+		return a.PlayedAt.Before(b.PlayedAt)
+	})
+	return entries, nil
+}
+
+func putDailyEntry(day *bolt.Bucket, entry DailyEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode daily entry: %w", err)
+	}
+	return day.Put([]byte(entry.PlayerID), data)
+}
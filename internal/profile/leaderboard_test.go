@@ -0,0 +1,89 @@
+package profile
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDailyLeaderboard(t *testing.T) {
+	s := openStore(t, t.TempDir())
+	defer s.Close()
+
+	const day = "2026-10-16"
+	results := []struct {
+		id, outcome string
+		duration    time.Duration
+		strikes     int
+	}{
+		{"user:slow", "defused", 3 * time.Minute, 0},
+		{"user:boom", "exploded", time.Minute, 3},
+		{"user:fast", "defused", 2 * time.Minute, 2},
+		{"user:tied", "defused", 2 * time.Minute, 1},
+	}
+	for _, r := range results {
+		ranked, err := s.ClaimDaily(day, r.id, r.id[5:])
+		if err != nil || !ranked {
+			t.Fatalf("ClaimDaily(%s) = %v, %v; want a ranked attempt", r.id, ranked, err)
+		}
+		if err := s.FinishDaily(day, r.id, r.outcome, r.duration, r.strikes); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.ClaimDaily(day, "user:quitter", "quitter"); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := s.DailyLeaderboard(day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var order []string
+	for _, e := range entries {
+		order = append(order, e.Nickname)
+	}
+	want := []string{"tied", "fast", "slow", "boom", "quitter"}
+	if len(order) != len(want) {
+		t.Fatalf("leaderboard = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("leaderboard = %v, want %v", order, want)
+		}
+	}
+
+	other, err := s.DailyLeaderboard("2026-10-17")
+	if err != nil || len(other) != 0 {
+		t.Errorf("next day's leaderboard = %v, %v; want it empty", other, err)
+	}
+}
+
+func TestDailyAttemptIsRankedOnce(t *testing.T) {
+	s := openStore(t, t.TempDir())
+	defer s.Close()
+
+	const day = "2026-10-16"
+	if ranked, err := s.ClaimDaily(day, "user:ada", "Ada"); err != nil || !ranked {
+		t.Fatalf("first claim = %v, %v; want ranked", ranked, err)
+	}
+	if err := s.FinishDaily(day, "user:ada", "exploded", time.Minute, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	if ranked, err := s.ClaimDaily(day, "user:ada", "Ada"); err != nil || ranked {
+		t.Errorf("second claim = %v, %v; want practice only", ranked, err)
+	}
+	if err := s.FinishDaily(day, "user:ada", "defused", time.Second, 0); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := s.DailyLeaderboard(day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Outcome != "exploded" {
+		t.Errorf("leaderboard = %+v, want only the first result", entries)
+	}
+
+	if err := s.FinishDaily(day, "user:nobody", "defused", time.Second, 0); err == nil {
+		t.Error("finished an attempt that was never claimed")
+	}
+}
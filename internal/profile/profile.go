@@ -0,0 +1,263 @@
+// Package profile persists players between SSH sessions. Profiles live in a
+// single bbolt file under the server's data directory and are keyed by the
+// fingerprint of the player's SSH public key, falling back to the SSH
+// username for sessions that connect without one.
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	bolt "go.etcd.io/bbolt"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+const (
+	// MaxNicknameLength is the longest nickname a player may choose.
+	MaxNicknameLength = 16
+
+	// maxHistory caps how many past games are kept per profile.
+	maxHistory = 100
+
+	dbFile = "profiles.db"
+)
+
+var profilesBucket = []byte("profiles")
+
+var ErrInvalidNickname = errors.New("nickname must be 1-16 letters, digits, spaces, '-' or '_'")
+
+// Preferences are per-player settings carried between sessions.
+type Preferences struct {
+	Theme string `json:"theme,omitempty"`
+
+	// Campaign locks each mission section until the previous one is done.
+	Campaign bool `json:"campaign,omitempty"`
+
+	// Keybindings map a key the player presses to the key the game listens
+	// for in its place, such as "w" to "up".
+	Keybindings map[string]string `json:"keybindings,omitempty"`
+}
+
+// GameRecord summarises one finished game. Mission is set for the built-in
+// missions only.
+type GameRecord struct {
+	PlayedAt   time.Time     `json:"played_at"`
+	Mode       string        `json:"mode"`
+	Mission    string        `json:"mission,omitempty"`
+	Outcome    string        `json:"outcome"`
+	Duration   time.Duration `json:"duration"`
+	Strikes    int           `json:"strikes"`
+	MaxStrikes int           `json:"max_strikes"`
+	Modules    int           `json:"modules"`
+	Solved     int           `json:"solved"`
+}
+
+// MissionResult is a player's record on one mission. BestTime and
+// FewestStrikes only count defused attempts and may come from different runs.
+type MissionResult struct {
+	Attempts      int           `json:"attempts"`
+	Completed     bool          `json:"completed"`
+	BestTime      time.Duration `json:"best_time,omitempty"`
+	FewestStrikes int           `json:"fewest_strikes,omitempty"`
+}
+
+func (r *MissionResult) record(game GameRecord) {
+	r.Attempts++
+	if game.Outcome != "defused" {
+		return
+	}
+	if !r.Completed || game.Duration < r.BestTime {
+		r.BestTime = game.Duration
+	}
+	if !r.Completed || game.Strikes < r.FewestStrikes {
+		r.FewestStrikes = game.Strikes
+	}
+	r.Completed = true
+}
+
+type Profile struct {
+	ID        string                   `json:"id"`
+	Nickname  string                   `json:"nickname"`
+	Prefs     Preferences              `json:"prefs"`
+	History   []GameRecord             `json:"history,omitempty"`
+	Missions  map[string]MissionResult `json:"missions,omitempty"`
+	CreatedAt time.Time                `json:"created_at"`
+	LastSeen  time.Time                `json:"last_seen"`
+}
+
+// IsNew reports whether the player has yet to pick a nickname.
+func (p *Profile) IsNew() bool {
+	return p.Nickname == ""
+}
+
+// ID identifies the player behind an SSH session. Keyless sessions are known
+// by username under their own prefix, so they never reach a keyed profile.
+func ID(key gossh.PublicKey, user string) string {
+	if key != nil {
+		return "key:" + gossh.FingerprintSHA256(key)
+	}
+	return "user:" + user
+}
+
+// ValidateNickname trims name and checks that it is fit to show to others.
+func ValidateNickname(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len([]rune(name)) > MaxNicknameLength {
+		return "", ErrInvalidNickname
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != ' ' && r != '-' && r != '_' {
+			return "", ErrInvalidNickname
+		}
+	}
+	return name, nil
+}
+
+// Store is safe for concurrent use by every session.
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens, creating if needed, the profile database in dir.
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create data dir: %w", err)
+	}
+	db, err := bolt.Open(filepath.Join(dir, dbFile), 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open profile database: %w", err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{profilesBucket, dailyBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialise profile database: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Load returns the profile for id, marking it as seen. Unknown players get a
+// fresh profile, which is saved along with the visit.
+func (s *Store) Load(id string) (*Profile, error) {
+	var p *Profile
+	err := s.update(id, func(existing *Profile) error {
+		existing.LastSeen = time.Now()
+		p = existing
+		return nil
+	})
+	return p, err
+}
+
+// SetNickname validates and stores the player's nickname.
+func (s *Store) SetNickname(id, name string) (*Profile, error) {
+	name, err := ValidateNickname(name)
+	if err != nil {
+		return nil, err
+	}
+	var p *Profile
+	err = s.update(id, func(existing *Profile) error {
+		existing.Nickname = name
+		p = existing
+		return nil
+	})
+	return p, err
+}
+
+// SetTheme stores the player's theme, leaving their other preferences as
+// they are in the database rather than in the caller's copy.
+func (s *Store) SetTheme(id, theme string) (*Profile, error) {
+	var p *Profile
+	err := s.update(id, func(existing *Profile) error {
+		existing.Prefs.Theme = theme
+		p = existing
+		return nil
+	})
+	return p, err
+}
+
+// SetCampaign turns campaign mode on or off for the player.
+func (s *Store) SetCampaign(id string, on bool) (*Profile, error) {
+	var p *Profile
+	err := s.update(id, func(existing *Profile) error {
+		existing.Prefs.Campaign = on
+		p = existing
+		return nil
+	})
+	return p, err
+}
+
+// SetKeybindings replaces the player's keybindings.
+func (s *Store) SetKeybindings(id string, bindings map[string]string) (*Profile, error) {
+	var p *Profile
+	err := s.update(id, func(existing *Profile) error {
+		existing.Prefs.Keybindings = bindings
+		p = existing
+		return nil
+	})
+	return p, err
+}
+
+// RecordGame appends a finished game to the player's history, dropping the
+// oldest entries beyond maxHistory, and updates their mission results.
+func (s *Store) RecordGame(id string, record GameRecord) (*Profile, error) {
+	var p *Profile
+	err := s.update(id, func(existing *Profile) error {
+		existing.History = append(existing.History, record)
+		if len(existing.History) > maxHistory {
+			existing.History = existing.History[len(existing.History)-maxHistory:]
+		}
+		if record.Mission != "" {
+			if existing.Missions == nil {
+				existing.Missions = make(map[string]MissionResult)
+			}
+			result := existing.Missions[record.Mission]
+			result.record(record)
+			existing.Missions[record.Mission] = result
+		}
+		p = existing
+		return nil
+	})
+	return p, err
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// update applies fn to the stored profile, or to a new one, in a single
+// transaction so concurrent sessions for the same player don't lose writes.
+func (s *Store) update(id string, fn func(*Profile) error) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(profilesBucket)
+
+		now := time.Now()
+		p := &Profile{ID: id, CreatedAt: now, LastSeen: now}
+		if data := bucket.Get([]byte(id)); data != nil {
+			if err := json.Unmarshal(data, p); err != nil {
+				return fmt.Errorf("failed to decode profile %s: %w", id, err)
+			}
+		}
+
+		if err := fn(p); err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(p)
+		if err != nil {
+			return fmt.Errorf("failed to encode profile %s: %w", id, err)
+		}
+		return bucket.Put([]byte(id), data)
+	})
+}
@@ -0,0 +1,187 @@
+package profile
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func openStore(t *testing.T, dir string) *Store {
+	t.Helper()
+	s, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestProfilePersists(t *testing.T) {
+	dir := t.TempDir()
+	s := openStore(t, dir)
+
+	p, err := s.Load("key:abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.IsNew() {
+		t.Fatalf("first load = %+v, want a new profile", p)
+	}
+
+	if _, err := s.SetNickname("key:abc", "  Zane "); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.SetTheme("key:abc", "deuteranopia"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.RecordGame("key:abc", GameRecord{Mode: "level 1", Outcome: "defused"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	s = openStore(t, dir)
+	defer s.Close()
+	p, err = s.Load("key:abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Nickname != "Zane" || p.Prefs.Theme != "deuteranopia" {
+		t.Errorf("reloaded profile = %+v", p)
+	}
+	if len(p.History) != 1 || p.History[0].Outcome != "defused" {
+		t.Errorf("history = %+v, want the recorded game", p.History)
+	}
+	if p.CreatedAt.After(p.LastSeen) {
+		t.Errorf("created %v after last seen %v", p.CreatedAt, p.LastSeen)
+	}
+}
+
+func TestHistoryIsCapped(t *testing.T) {
+	s := openStore(t, t.TempDir())
+	defer s.Close()
+
+	for i := 0; i < maxHistory+5; i++ {
+		if _, err := s.RecordGame("user:ada", GameRecord{Strikes: i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	p, err := s.Load("user:ada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.History) != maxHistory || p.History[0].Strikes != 5 {
+		t.Errorf("kept %d games starting at %d, want the newest %d", len(p.History), p.History[0].Strikes, maxHistory)
+	}
+}
+
+func TestMissionResults(t *testing.T) {
+	s := openStore(t, t.TempDir())
+	defer s.Close()
+
+	games := []GameRecord{
+		{Mission: "THE_FIRST_BOMB", Outcome: "exploded", Duration: time.Minute},
+		{Mission: "THE_FIRST_BOMB", Outcome: "defused", Duration: 3 * time.Minute, Strikes: 0},
+		{Mission: "THE_FIRST_BOMB", Outcome: "defused", Duration: 2 * time.Minute, Strikes: 2},
+		{Mission: "THE_FIRST_BOMB", Outcome: "timeout", Duration: 5 * time.Minute},
+		{Mode: "level 1", Outcome: "defused"},
+	}
+	var p *Profile
+	for _, game := range games {
+		var err error
+		if p, err = s.RecordGame("user:ada", game); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := MissionResult{Attempts: 4, Completed: true, BestTime: 2 * time.Minute, FewestStrikes: 0}
+	if got := p.Missions["THE_FIRST_BOMB"]; got != want {
+		t.Errorf("mission result = %+v, want %+v", got, want)
+	}
+	if len(p.Missions) != 1 {
+		t.Errorf("missions = %v, want only the preset mission", p.Missions)
+	}
+}
+
+func TestConcurrentRecords(t *testing.T) {
+	s := openStore(t, t.TempDir())
+	defer s.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := s.RecordGame("user:ada", GameRecord{}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	p, err := s.Load("user:ada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.History) != 20 {
+		t.Errorf("recorded %d games, want 20", len(p.History))
+	}
+}
+
+func TestPreferencesAreSetIndependently(t *testing.T) {
+	s := openStore(t, t.TempDir())
+	defer s.Close()
+
+	// Two sessions for the same player change different preferences.
+	if _, err := s.SetTheme("key:abc", "light"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.SetCampaign("key:abc", true); err != nil {
+		t.Fatal(err)
+	}
+	p, err := s.SetKeybindings("key:abc", map[string]string{"w": "up"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Prefs.Theme != "light" || !p.Prefs.Campaign || p.Prefs.Keybindings["w"] != "up" {
+		t.Errorf("prefs = %+v, want the theme, campaign mode and keybindings kept", p.Prefs)
+	}
+}
+
+func TestValidateNickname(t *testing.T) {
+	for _, name := range []string{"", "   ", strings.Repeat("a", MaxNicknameLength+1), "bad\x1b[31m", "a/b"} {
+		if _, err := ValidateNickname(name); !errors.Is(err, ErrInvalidNickname) {
+			t.Errorf("ValidateNickname(%q) = %v, want ErrInvalidNickname", name, err)
+		}
+	}
+	if got, err := ValidateNickname(" Bomb_Squad-7 "); err != nil || got != "Bomb_Squad-7" {
+		t.Errorf("ValidateNickname = %q, %v", got, err)
+	}
+}
+
+func TestID(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := gossh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if id := ID(key, "ada"); !strings.HasPrefix(id, "key:SHA256:") {
+		t.Errorf("ID with key = %q, want a fingerprint", id)
+	}
+	if id := ID(nil, "ada"); id != "user:ada" {
+		t.Errorf("ID without key = %q, want user:ada", id)
+	}
+	// A keyless session can't pass itself off as a key.
+	if id := ID(nil, ID(key, "ada")); !strings.HasPrefix(id, "user:key:") {
+		t.Errorf("ID without key = %q, want it under user:", id)
+	}
+}
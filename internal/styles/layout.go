@@ -0,0 +1,145 @@
+package styles
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// MinWidth and MinHeight are the smallest terminal the UI supports.
+	MinWidth  = 40
+	MinHeight = 20
+
+	// MaxWidth is the widest the header, content and footer boxes grow,
+	// borders included. Wider terminals leave the extra space empty.
+	MaxWidth = 72
+
+	// shortHeight is the terminal height below which the content box drops
+	// its blank padding rows.
+	shortHeight = 30
+)
+
+// Layout sizes the stacked header, content and footer boxes every screen is
+// built from to fit the terminal. A zero size means it isn't known yet, in
+// which case the boxes are drawn at full size.
+type Layout struct {
+	Width  int
+	Height int
+}
+
+func NewLayout(width, height int) Layout {
+	return Layout{Width: width, Height: height}
+}
+
+// TooSmall reports whether the terminal is below MinWidth by MinHeight.
+func (l Layout) TooSmall() bool {
+	if l.Width <= 0 || l.Height <= 0 {
+		return false
+	}
+	return l.Width < MinWidth || l.Height < MinHeight
+}
+
+// Compact reports whether the boxes are narrower than usual, so views should
+// switch to their abbreviated forms.
+func (l Layout) Compact() bool {
+	return l.BoxWidth() < MaxWidth
+}
+
+// BoxWidth is the outer width of the boxes, borders included.
+func (l Layout) BoxWidth() int {
+	if l.Width <= 0 {
+		return MaxWidth
+	}
+	return min(max(l.Width, MinWidth), MaxWidth)
+}
+
+// TextWidth is the room left for text inside the content box.
+func (l Layout) TextWidth() int {
+	_, horizontal := l.contentPadding()
+	return l.BoxWidth() - 2 - 2*horizontal
+}
+
+func (l Layout) contentPadding() (vertical, horizontal int) {
+	vertical, horizontal = 1, 2
+	if l.Compact() {
+		horizontal = 1
+	}
+	if l.Height > 0 && l.Height < shortHeight {
+		vertical = 0
+	}
+	return vertical, horizontal
+}
+
+// BodyRows is how many lines of text fit in the content box between the
+// rendered header and footer boxes, or 0 if the terminal's height isn't known
+// yet.
+func (l Layout) BodyRows(header, footer string) int {
+	if l.Height <= 0 {
+		return 0
+	}
+	vertical, _ := l.contentPadding()
+	return max(l.Height-lipgloss.Height(header)-lipgloss.Height(footer)-2-2*vertical, 1)
+}
+
+func (l Layout) Header() lipgloss.Style {
+	return HeaderBox.Width(l.BoxWidth() - 2)
+}
+
+func (l Layout) Content() lipgloss.Style {
+	vertical, horizontal := l.contentPadding()
+	return ContentBox.Width(l.BoxWidth()-2).Padding(vertical, horizontal)
+}
+
+func (l Layout) Footer() lipgloss.Style {
+	return FooterBox.Width(l.BoxWidth() - 2)
+}
+
+// Hint renders a key binding hint in the footer box, wrapped to fit.
+func (l Layout) Hint(hint string) string {
+	return l.Footer().Render(Help.Render(WrapHint(hint, l.BoxWidth()-4)))
+}
+
+// TooSmallView replaces the UI while the terminal is below the minimum size.
+func (l Layout) TooSmallView() string {
+	return Center(
+		lipgloss.JoinVertical(
+			lipgloss.Center,
+			Warning.Render("Terminal too small"),
+			"",
+			Help.Render(fmt.Sprintf("Need %dx%d, have %dx%d", MinWidth, MinHeight, l.Width, l.Height)),
+		),
+		l.Width, l.Height,
+	)
+}
+
+// hintSeparator matches the gaps between key bindings in a hint, e.g. the
+// spaces in "[ENTER] Select  [ESC] Back" or the bar in "[1-4] Press | [ESC] Back".
+var hintSeparator = regexp.MustCompile(`  +| \| `)
+
+// WrapHint breaks hint into lines no wider than width, only ever between key
+// bindings so that a key is never split from what it does.
+func WrapHint(hint string, width int) string {
+	if lipgloss.Width(hint) <= width {
+		return hint
+	}
+
+	var lines []string
+	line, separator, start := "", "", 0
+	for _, gap := range append(hintSeparator.FindAllStringIndex(hint, -1), []int{len(hint), len(hint)}) {
+		binding := hint[start:gap[0]]
+		switch {
+		case line == "":
+			line = binding
+		case lipgloss.Width(line+separator+binding) <= width:
+			line += separator + binding
+		default:
+			lines = append(lines, line)
+			line = binding
+		}
+		separator, start = hint[gap[0]:gap[1]], gap[1]
+	}
+	return strings.Join(append(lines, line), "\n")
+}
@@ -0,0 +1,68 @@
+package styles
+
+import "testing"
+
+func TestLayoutSizes(t *testing.T) {
+	tests := []struct {
+		width, height int
+		box           int
+		compact       bool
+		tooSmall      bool
+	}{
+		{0, 0, MaxWidth, false, false},
+		{200, 60, MaxWidth, false, false},
+		{72, 20, MaxWidth, false, false},
+		{60, 30, 60, true, false},
+		{40, 20, 40, true, false},
+		{39, 20, 40, true, true},
+		{80, 19, MaxWidth, false, true},
+	}
+	for _, tt := range tests {
+		l := NewLayout(tt.width, tt.height)
+		if got := l.BoxWidth(); got != tt.box {
+			t.Errorf("%dx%d: BoxWidth = %d, want %d", tt.width, tt.height, got, tt.box)
+		}
+		if got := l.Compact(); got != tt.compact {
+			t.Errorf("%dx%d: Compact = %v, want %v", tt.width, tt.height, got, tt.compact)
+		}
+		if got := l.TooSmall(); got != tt.tooSmall {
+			t.Errorf("%dx%d: TooSmall = %v, want %v", tt.width, tt.height, got, tt.tooSmall)
+		}
+	}
+}
+
+func TestWrapHint(t *testing.T) {
+	tests := []struct {
+		hint  string
+		width int
+		want  string
+	}{
+		{"[ENTER] Select  [ESC] Back", 40, "[ENTER] Select  [ESC] Back"},
+		{"[ENTER] Select  [ESC] Back", 20, "[ENTER] Select\n[ESC] Back"},
+		{"[1-6] Cut wire | [ESC] Back to bomb", 20, "[1-6] Cut wire\n[ESC] Back to bomb"},
+		{"[↑/↓] Navigate  [ENTER] Select  [Q] Quit", 33, "[↑/↓] Navigate  [ENTER] Select\n[Q] Quit"},
+	}
+	for _, tt := range tests {
+		if got := WrapHint(tt.hint, tt.width); got != tt.want {
+			t.Errorf("WrapHint(%q, %d) = %q, want %q", tt.hint, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestBodyRows(t *testing.T) {
+	box := "┌─┐\n│ │\n└─┘"
+	tests := []struct {
+		width, height int
+		want          int
+	}{
+		{0, 0, 0},
+		{40, 20, 12},
+		{80, 40, 30},
+		{80, 8, 1},
+	}
+	for _, tt := range tests {
+		if got := NewLayout(tt.width, tt.height).BodyRows(box, box); got != tt.want {
+			t.Errorf("%dx%d: BodyRows = %d, want %d", tt.width, tt.height, got, tt.want)
+		}
+	}
+}
@@ -5,30 +5,33 @@ import (
 )
 
 var (
-	Title      = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#FF6B6B")).Padding(0, 1)
-	Subtitle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#AAAAAA"))
-	Help       = lipgloss.NewStyle().Foreground(lipgloss.Color("#666666"))
-	Warning    = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFD93D"))
-	Error      = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF4444"))
-	Success    = lipgloss.NewStyle().Foreground(lipgloss.Color("#6BCB77"))
+	Title      = lipgloss.NewStyle().Bold(true).Foreground(Colors.Red).Padding(0, 1)
+	Subtitle   = lipgloss.NewStyle().Foreground(Colors.Muted)
+	Help       = lipgloss.NewStyle().Foreground(Colors.Faint)
+	Warning    = lipgloss.NewStyle().Foreground(Colors.Yellow)
+	Error      = lipgloss.NewStyle().Foreground(Colors.Danger)
+	Success    = lipgloss.NewStyle().Foreground(Colors.Green)
 	ContentBox = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(1, 2).Width(70)
 	HeaderBox  = lipgloss.NewStyle().Border(lipgloss.DoubleBorder()).Padding(0, 1).Width(70)
 	FooterBox  = lipgloss.NewStyle().Border(lipgloss.NormalBorder()).Padding(0, 1).Width(70)
-	DialogBox  = lipgloss.NewStyle().Border(lipgloss.DoubleBorder()).Padding(1, 3).BorderForeground(lipgloss.Color("#FFD93D"))
+	DialogBox  = lipgloss.NewStyle().Border(lipgloss.DoubleBorder()).Padding(1, 3).BorderForeground(Colors.Yellow)
 	Normal     = lipgloss.NewStyle()
-	Solved     = lipgloss.NewStyle().Foreground(lipgloss.Color("#6BCB77")).Bold(true)
-	Pending    = lipgloss.NewStyle().Foreground(lipgloss.Color("#AAAAAA"))
-	Active     = lipgloss.NewStyle().Foreground(lipgloss.Color("#4ECDC4")).Bold(true)
-	Strike     = lipgloss.NewStyle().Background(lipgloss.Color("#FF4444")).Foreground(lipgloss.Color("#FFFFFF"))
+	Solved     = lipgloss.NewStyle().Foreground(Colors.Green).Bold(true)
+	Pending    = lipgloss.NewStyle().Foreground(Colors.Muted)
+	Active     = lipgloss.NewStyle().Foreground(Colors.Cyan).Bold(true)
+	Strike     = lipgloss.NewStyle().Background(Colors.Danger).Foreground(Colors.White)
+	Toast      = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1)
 )
 
 var (
-	Red    = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B"))
-	Blue   = lipgloss.NewStyle().Foreground(lipgloss.Color("#4DABF7"))
-	Yellow = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFD93D"))
-	Black  = lipgloss.NewStyle().Foreground(lipgloss.Color("#212529"))
-	White  = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
-	Green  = lipgloss.NewStyle().Foreground(lipgloss.Color("#6BCB77"))
+	Red    = lipgloss.NewStyle().Foreground(Colors.Red)
+	Orange = lipgloss.NewStyle().Foreground(Colors.Orange)
+	Blue   = lipgloss.NewStyle().Foreground(Colors.Blue)
+	Yellow = lipgloss.NewStyle().Foreground(Colors.Yellow)
+	Black  = lipgloss.NewStyle().Foreground(Colors.Black)
+	White  = lipgloss.NewStyle().Foreground(Colors.White)
+	Green  = lipgloss.NewStyle().Foreground(Colors.Green)
+	Pink   = lipgloss.NewStyle().Foreground(Colors.Pink)
 )
 
 func Center(s string, width, height int) string {
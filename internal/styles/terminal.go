@@ -0,0 +1,184 @@
+package styles
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+// Terminal is what a client's terminal can display. Views are always rendered
+// in true color with Unicode glyphs, since the lipgloss renderer is shared by
+// every session, and Render then converts them for the client. The zero value
+// passes views through unchanged.
+type Terminal struct {
+	Profile termenv.Profile
+
+	// ASCII is set for terminals that can't draw box-drawing, block and
+	// other symbol characters.
+	ASCII bool
+}
+
+// DetectTerminal works out what a client's terminal can display from its
+// TERM and the environment variables it sent. NO_COLOR turns color off
+// whatever the terminal supports.
+func DetectTerminal(term string, environ []string) Terminal {
+	env := make(map[string]string)
+	for _, kv := range environ {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			env[k] = v
+		}
+	}
+	if term == "" {
+		term = env["TERM"]
+	}
+	term = strings.ToLower(term)
+
+	t := Terminal{
+		Profile: colorProfile(term, strings.ToLower(env["COLORTERM"])),
+		ASCII:   !unicodeTerminal(term, env),
+	}
+	if env["NO_COLOR"] != "" {
+		t.Profile = termenv.Ascii
+	}
+	return t
+}
+
+func colorProfile(term, colorterm string) termenv.Profile {
+	switch {
+	case term == "" || term == "dumb":
+		return termenv.Ascii
+	case colorterm == "truecolor" || colorterm == "24bit",
+		strings.Contains(term, "truecolor"), strings.Contains(term, "24bit"), strings.HasSuffix(term, "-direct"),
+		strings.HasPrefix(term, "xterm-kitty"), strings.HasPrefix(term, "xterm-ghostty"),
+		strings.HasPrefix(term, "alacritty"), strings.HasPrefix(term, "wezterm"), strings.HasPrefix(term, "foot"):
+		return termenv.TrueColor
+	case strings.Contains(term, "256color"), colorterm != "":
+		return termenv.ANSI256
+	case strings.HasPrefix(term, "vt1"), strings.HasPrefix(term, "vt2"):
+		// The DEC terminals these name were monochrome.
+		return termenv.Ascii
+	default:
+		return termenv.ANSI
+	}
+}
+
+// unicodeTerminal reports whether a terminal can be trusted with the UI's
+// glyphs. A locale that is set but isn't UTF-8 rules them out, as do old
+// terminals and the Linux console, whose font lacks most of them.
+func unicodeTerminal(term string, env map[string]string) bool {
+	switch {
+	case term == "dumb", term == "linux", strings.HasPrefix(term, "vt"):
+		return false
+	}
+	for _, key := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale := strings.ToLower(env[key]); locale != "" {
+			return strings.Contains(locale, "utf-8") || strings.Contains(locale, "utf8")
+		}
+	}
+	return true
+}
+
+// Render converts a view rendered in true color with Unicode glyphs for t.
+func (t Terminal) Render(view string) string {
+	if t.Profile != termenv.TrueColor {
+		view = sgrSequence.ReplaceAllStringFunc(view, func(seq string) string {
+			return downsample(seq, t.Profile)
+		})
+	}
+	if t.ASCII {
+		view = asciiGlyphs.Replace(view)
+	}
+	return view
+}
+
+var sgrSequence = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// downsample rewrites the colors in an SGR escape sequence for profile.
+func downsample(seq string, profile termenv.Profile) string {
+	return rewriteColors(seq, func(c termenv.Color, bg bool) string {
+		if converted := profile.Convert(c); converted != nil {
+			return converted.Sequence(bg)
+		}
+		return ""
+	})
+}
+
+// rewriteColors replaces each color an SGR escape sequence sets with the
+// parameters convert returns for it, keeping other attributes such as bold.
+// Colors convert returns nothing for are dropped, along with the sequence if
+// it is left with nothing to set.
+func rewriteColors(seq string, convert func(c termenv.Color, bg bool) string) string {
+	params := strings.Split(seq[2:len(seq)-1], ";")
+	var kept []string
+	for i := 0; i < len(params); i++ {
+		p := params[i]
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			kept = append(kept, p)
+			continue
+		}
+
+		var c termenv.Color
+		bg := false
+		switch {
+		case (n == 38 || n == 48) && i+4 < len(params) && params[i+1] == "2":
+			c, bg = sgrRGB(params[i+2:i+5]), n == 48
+			i += 4
+		case (n == 38 || n == 48) && i+2 < len(params) && params[i+1] == "5":
+			index, _ := strconv.Atoi(params[i+2])
+			c, bg = termenv.ANSI256Color(index), n == 48
+			i += 2
+		case n >= 30 && n <= 37, n >= 40 && n <= 47:
+			c, bg = termenv.ANSIColor(n%10), n >= 40
+		case n >= 90 && n <= 97, n >= 100 && n <= 107:
+			c, bg = termenv.ANSIColor(n%10+8), n >= 100
+		default:
+			kept = append(kept, p)
+			continue
+		}
+		if s := convert(c, bg); s != "" {
+			kept = append(kept, s)
+		}
+	}
+	if len(kept) == 0 {
+		return ""
+	}
+	return "\x1b[" + strings.Join(kept, ";") + "m"
+}
+
+// sgrRGB is the color set by the red, green and blue parameters of an SGR
+// sequence.
+func sgrRGB(params []string) termenv.RGBColor {
+	var rgb [3]int
+	for i, p := range params {
+		rgb[i], _ = strconv.Atoi(p)
+	}
+	return termenv.RGBColor(fmt.Sprintf("#%02x%02x%02x", rgb[0], rgb[1], rgb[2]))
+}
+
+// asciiGlyphs swaps every non-ASCII character the UI draws for one of the
+// same width. Keypad symbols are left alone: they are what the manual
+// identifies the keys by.
+var asciiGlyphs = strings.NewReplacer(
+	// Borders and lines.
+	"─", "-", "━", "-", "═", "=", "│", "|", "┃", "|", "║", "|",
+	"╭", "+", "╮", "+", "╰", "+", "╯", "+",
+	"┌", "+", "┐", "+", "└", "+", "┘", "+", "├", "+", "┤", "+",
+	"┏", "+", "┓", "+", "┗", "+", "┛", "+",
+	"╔", "+", "╗", "+", "╚", "+", "╝", "+",
+	"╱", "/", "╲", `\`,
+	// Blocks.
+	"█", "#", "▓", "#",
+	// Shapes and arrows.
+	"●", "*", "○", "o", "◎", "@",
+	"▲", "^", "▼", "v", "◀", "<", "◄", "<", "▶", ">", "►", ">",
+	"↑", "^", "↓", "v", "←", "<", "→", ">",
+	// Marks.
+	"✓", "v", "✕", "x", "✖", "x", "×", "x", "⚠", "!", "ℹ", "i",
+	// Spinner frames.
+	"⠋", "|", "⠙", "/", "⠹", "-", "⠸", `\`, "⠼", "|",
+	"⠴", "/", "⠦", "-", "⠧", `\`, "⠇", "|", "⠏", "/",
+)
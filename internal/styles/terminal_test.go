@@ -0,0 +1,52 @@
+package styles
+
+import (
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestDetectTerminal(t *testing.T) {
+	tests := []struct {
+		term    string
+		environ []string
+		want    Terminal
+	}{
+		{"xterm-256color", nil, Terminal{Profile: termenv.ANSI256}},
+		{"xterm-256color", []string{"COLORTERM=truecolor"}, Terminal{Profile: termenv.TrueColor}},
+		{"xterm-kitty", nil, Terminal{Profile: termenv.TrueColor}},
+		{"screen", nil, Terminal{Profile: termenv.ANSI}},
+		{"", []string{"TERM=xterm-256color"}, Terminal{Profile: termenv.ANSI256}},
+		{"xterm-256color", []string{"NO_COLOR=1"}, Terminal{Profile: termenv.Ascii}},
+		{"xterm-256color", []string{"NO_COLOR="}, Terminal{Profile: termenv.ANSI256}},
+		{"xterm-256color", []string{"LANG=en_US.UTF-8"}, Terminal{Profile: termenv.ANSI256}},
+		{"xterm-256color", []string{"LANG=en_US.UTF-8", "LC_ALL=C"}, Terminal{Profile: termenv.ANSI256, ASCII: true}},
+		{"linux", nil, Terminal{Profile: termenv.ANSI, ASCII: true}},
+		{"vt100", nil, Terminal{Profile: termenv.Ascii, ASCII: true}},
+		{"dumb", nil, Terminal{Profile: termenv.Ascii, ASCII: true}},
+	}
+	for _, tt := range tests {
+		if got := DetectTerminal(tt.term, tt.environ); got != tt.want {
+			t.Errorf("DetectTerminal(%q, %q) = %+v, want %+v", tt.term, tt.environ, got, tt.want)
+		}
+	}
+}
+
+func TestTerminalRender(t *testing.T) {
+	view := "\x1b[1;38;2;255;107;107mBOOM\x1b[0m ╭─╮ \x1b[48;5;196mX\x1b[0m \x1b[31mred\x1b[0m"
+
+	tests := []struct {
+		terminal Terminal
+		want     string
+	}{
+		{Terminal{}, view},
+		{Terminal{Profile: termenv.ANSI256}, "\x1b[1;38;5;203mBOOM\x1b[0m ╭─╮ \x1b[48;5;196mX\x1b[0m \x1b[31mred\x1b[0m"},
+		{Terminal{Profile: termenv.ANSI}, "\x1b[1;91mBOOM\x1b[0m ╭─╮ \x1b[101mX\x1b[0m \x1b[31mred\x1b[0m"},
+		{Terminal{Profile: termenv.Ascii, ASCII: true}, "\x1b[1mBOOM\x1b[0m +-+ X\x1b[0m red\x1b[0m"},
+	}
+	for _, tt := range tests {
+		if got := tt.terminal.Render(view); got != tt.want {
+			t.Errorf("%+v: Render = %q, want %q", tt.terminal, got, tt.want)
+		}
+	}
+}
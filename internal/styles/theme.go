@@ -0,0 +1,296 @@
+package styles
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Palette is every color the UI draws with. The game colors double as the
+// accents: Red for titles, Yellow for warnings, Green for success and so on.
+type Palette struct {
+	Red    lipgloss.Color
+	Orange lipgloss.Color
+	Yellow lipgloss.Color
+	Green  lipgloss.Color
+	Blue   lipgloss.Color
+	Cyan   lipgloss.Color
+	Pink   lipgloss.Color
+	White  lipgloss.Color
+	Black  lipgloss.Color
+
+	Danger lipgloss.Color
+	Muted  lipgloss.Color
+	Faint  lipgloss.Color
+
+	// The Simon buttons' colors while unlit.
+	DimRed    lipgloss.Color
+	DimBlue   lipgloss.Color
+	DimGreen  lipgloss.Color
+	DimYellow lipgloss.Color
+
+	// The modules' hardware: Lamp and Wire for the Morse light, Readout
+	// for its frequency, Display for the needy timers and Screen for the
+	// vent's terminal. Casing and Trim are the borders of boxes and their
+	// buttons, Panel a button with no color of its own, and Ink and
+	// Engraving the labels printed on bright buttons.
+	Lamp      lipgloss.Color
+	Wire      lipgloss.Color
+	Readout   lipgloss.Color
+	Display   lipgloss.Color
+	Screen    lipgloss.Color
+	Casing    lipgloss.Color
+	Trim      lipgloss.Color
+	Panel     lipgloss.Color
+	Ink       lipgloss.Color
+	Engraving lipgloss.Color
+}
+
+func (p Palette) colors() []lipgloss.Color {
+	return []lipgloss.Color{
+		p.Red, p.Orange, p.Yellow, p.Green, p.Blue, p.Cyan, p.Pink, p.White, p.Black,
+		p.Danger, p.Muted, p.Faint,
+		p.DimRed, p.DimBlue, p.DimGreen, p.DimYellow,
+		p.Lamp, p.Wire, p.Readout, p.Display, p.Screen, p.Casing, p.Trim, p.Panel, p.Ink, p.Engraving,
+	}
+}
+
+// Theme is a palette a session can choose to play in. Views are always
+// rendered in Colors, the dark theme's palette, since the lipgloss renderer
+// is shared by every session; Recolor then swaps in the theme's own.
+type Theme struct {
+	ID      string
+	Name    string
+	Palette Palette
+
+	recolor map[termenv.RGBColor]lipgloss.Color
+}
+
+func newTheme(id, name string, palette Palette) Theme {
+	t := Theme{ID: id, Name: name, Palette: palette, recolor: make(map[termenv.RGBColor]lipgloss.Color)}
+	theirs := palette.colors()
+	for i, ours := range darkPalette.colors() {
+		if theirs[i] != ours {
+			t.recolor[rgbKey(termenv.RGBColor(ours))] = theirs[i]
+		}
+	}
+	return t
+}
+
+var darkPalette = Palette{
+	Red:    "#FF6B6B",
+	Orange: "#FF8700",
+	Yellow: "#FFD93D",
+	Green:  "#6BCB77",
+	Blue:   "#4DABF7",
+	Cyan:   "#4ECDC4",
+	Pink:   "#FFAFFF",
+	White:  "#FFFFFF",
+	Black:  "#868E96",
+
+	Danger: "#FF4444",
+	Muted:  "#AAAAAA",
+	Faint:  "#666666",
+
+	DimRed:    "#8B0000",
+	DimBlue:   "#00008B",
+	DimGreen:  "#006400",
+	DimYellow: "#8B8B00",
+
+	Lamp:      "#FFB000",
+	Wire:      "#8B4513",
+	Readout:   "#FFD700",
+	Display:   "#FF0000",
+	Screen:    "#5B8C45",
+	Casing:    "#555555",
+	Trim:      "#888888",
+	Panel:     "#333333",
+	Ink:       "#000000",
+	Engraving: "#222222",
+}
+
+// Colors is the palette views are rendered in.
+var Colors = darkPalette
+
+var (
+	Dark = newTheme("dark", "Dark", darkPalette)
+
+	Light = newTheme("light", "Light", Palette{
+		Red:    "#C92A2A",
+		Orange: "#D9480F",
+		Yellow: "#B08800",
+		Green:  "#2B8A3E",
+		Blue:   "#1864AB",
+		Cyan:   "#0B7285",
+		Pink:   "#C2255C",
+		White:  "#ADB5BD",
+		Black:  "#212529",
+
+		Danger: "#E03131",
+		Muted:  "#495057",
+		Faint:  "#868E96",
+
+		DimRed:    "#FFC9C9",
+		DimBlue:   "#A5D8FF",
+		DimGreen:  "#B2F2BB",
+		DimYellow: "#FFEC99",
+
+		Lamp:      "#F08C00",
+		Wire:      "#6F3B12",
+		Readout:   "#B08800",
+		Display:   "#E03131",
+		Screen:    "#2B8A3E",
+		Casing:    "#ADB5BD",
+		Trim:      "#868E96",
+		Panel:     "#DEE2E6",
+		Ink:       "#000000",
+		Engraving: "#212529",
+	})
+
+	HighContrast = newTheme("high-contrast", "High contrast", Palette{
+		Red:    "#FF5555",
+		Orange: "#FFA500",
+		Yellow: "#FFFF00",
+		Green:  "#00FF00",
+		Blue:   "#5599FF",
+		Cyan:   "#00FFFF",
+		Pink:   "#FF77FF",
+		White:  "#FFFFFF",
+		Black:  "#A0A0A0",
+
+		Danger: "#FF0000",
+		Muted:  "#E0E0E0",
+		Faint:  "#C0C0C0",
+
+		DimRed:    "#550000",
+		DimBlue:   "#000055",
+		DimGreen:  "#005500",
+		DimYellow: "#555500",
+
+		Lamp:      "#FFC000",
+		Wire:      "#B5651D",
+		Readout:   "#FFFF00",
+		Display:   "#FF0000",
+		Screen:    "#00FF00",
+		Casing:    "#A0A0A0",
+		Trim:      "#C0C0C0",
+		Panel:     "#404040",
+		Ink:       "#000000",
+		Engraving: "#000000",
+	})
+
+	// Deuteranopia uses the Okabe-Ito palette, which stays distinct for
+	// every common form of color blindness.
+	Deuteranopia = newTheme("deuteranopia", "Deuteranopia", Palette{
+		Red:    "#D55E00",
+		Orange: "#E69F00",
+		Yellow: "#F0E442",
+		Green:  "#009E73",
+		Blue:   "#0072B2",
+		Cyan:   "#56B4E9",
+		Pink:   "#CC79A7",
+		White:  "#FFFFFF",
+		Black:  "#8C8C8C",
+
+		Danger: "#D55E00",
+		Muted:  "#BBBBBB",
+		Faint:  "#888888",
+
+		DimRed:    "#5E2900",
+		DimBlue:   "#00324F",
+		DimGreen:  "#004633",
+		DimYellow: "#6B6520",
+
+		Lamp:      "#E69F00",
+		Wire:      "#8C5A2B",
+		Readout:   "#F0E442",
+		Display:   "#D55E00",
+		Screen:    "#009E73",
+		Casing:    "#555555",
+		Trim:      "#888888",
+		Panel:     "#333333",
+		Ink:       "#000000",
+		Engraving: "#222222",
+	})
+
+	// Protanopia uses IBM's color blind safe palette. Red is shifted to
+	// magenta, since protanopes see pure reds as dark and muddy.
+	Protanopia = newTheme("protanopia", "Protanopia", Palette{
+		Red:    "#DC267F",
+		Orange: "#FE6100",
+		Yellow: "#FFB000",
+		Green:  "#40B0A6",
+		Blue:   "#648FFF",
+		Cyan:   "#A0C4FF",
+		Pink:   "#785EF0",
+		White:  "#FFFFFF",
+		Black:  "#8C8C8C",
+
+		Danger: "#DC267F",
+		Muted:  "#BBBBBB",
+		Faint:  "#888888",
+
+		DimRed:    "#5C1035",
+		DimBlue:   "#2A3C6B",
+		DimGreen:  "#1B4A45",
+		DimYellow: "#6B4A00",
+
+		Lamp:      "#FFB000",
+		Wire:      "#7A4A1E",
+		Readout:   "#FFB000",
+		Display:   "#DC267F",
+		Screen:    "#40B0A6",
+		Casing:    "#555555",
+		Trim:      "#888888",
+		Panel:     "#333333",
+		Ink:       "#000000",
+		Engraving: "#222222",
+	})
+)
+
+// Themes lists the built-in themes in the order players cycle through them.
+var Themes = []Theme{Dark, Light, HighContrast, Deuteranopia, Protanopia}
+
+// ThemeByID finds a built-in theme, e.g. from a saved preference.
+func ThemeByID(id string) (Theme, bool) {
+	for _, t := range Themes {
+		if t.ID == id {
+			return t, true
+		}
+	}
+	return Theme{}, false
+}
+
+// Next is the theme delta places after t in Themes, wrapping around.
+func (t Theme) Next(delta int) Theme {
+	for i, theme := range Themes {
+		if theme.ID == t.ID {
+			return Themes[((i+delta)%len(Themes)+len(Themes))%len(Themes)]
+		}
+	}
+	return Dark
+}
+
+// Recolor converts a view rendered in Colors to t's palette.
+func (t Theme) Recolor(view string) string {
+	if len(t.recolor) == 0 {
+		return view
+	}
+	return sgrSequence.ReplaceAllStringFunc(view, func(seq string) string {
+		return rewriteColors(seq, func(c termenv.Color, bg bool) string {
+			if rgb, ok := c.(termenv.RGBColor); ok {
+				if theirs, ok := t.recolor[rgb]; ok {
+					return termenv.RGBColor(theirs).Sequence(bg)
+				}
+			}
+			return c.Sequence(bg)
+		})
+	})
+}
+
+// rgbKey is the color a view will contain after c is rendered, which may
+// have been rounded from its hex code.
+func rgbKey(c termenv.RGBColor) termenv.RGBColor {
+	return sgrRGB(strings.Split(c.Sequence(false), ";")[2:])
+}
@@ -0,0 +1,61 @@
+package styles
+
+import (
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestThemeRecolor(t *testing.T) {
+	red := "\x1b[1;" + termenv.RGBColor(Colors.Red).Sequence(false) + "mBOOM\x1b[0m"
+	strike := "\x1b[" + termenv.RGBColor(Colors.Danger).Sequence(true) + "mX\x1b[0m"
+	other := "\x1b[38;2;1;2;3mx\x1b[0m \x1b[31mred\x1b[0m"
+	view := red + " " + strike + " " + other
+
+	if got := Dark.Recolor(view); got != view {
+		t.Errorf("Dark.Recolor changed the view: %q", got)
+	}
+
+	want := "\x1b[1;38;2;213;94;0mBOOM\x1b[0m \x1b[48;2;213;94;0mX\x1b[0m " + other
+	if got := Deuteranopia.Recolor(view); got != want {
+		t.Errorf("Deuteranopia.Recolor = %q, want %q", got, want)
+	}
+}
+
+// Recolor finds colors by their value, so the palette views are rendered in
+// can't use one twice, and every theme must replace all of them.
+func TestPaletteColors(t *testing.T) {
+	seen := make(map[termenv.RGBColor]bool)
+	for _, c := range darkPalette.colors() {
+		key := rgbKey(termenv.RGBColor(c))
+		if seen[key] {
+			t.Errorf("the dark palette uses %s twice", c)
+		}
+		seen[key] = true
+	}
+	for _, theme := range Themes {
+		for i, c := range theme.Palette.colors() {
+			if c == "" {
+				t.Errorf("%s theme is missing color %d", theme.Name, i)
+			}
+		}
+	}
+}
+
+func TestThemeByID(t *testing.T) {
+	for _, theme := range Themes {
+		if got, ok := ThemeByID(theme.ID); !ok || got.Name != theme.Name {
+			t.Errorf("ThemeByID(%q) = %q, %v", theme.ID, got.Name, ok)
+		}
+	}
+	if _, ok := ThemeByID("solarized"); ok {
+		t.Error("ThemeByID found an unknown theme")
+	}
+
+	if got := Dark.Next(-1); got.ID != Protanopia.ID {
+		t.Errorf("Dark.Next(-1) = %q, want protanopia", got.ID)
+	}
+	if got := Protanopia.Next(1); got.ID != Dark.ID {
+		t.Errorf("Protanopia.Next(1) = %q, want dark", got.ID)
+	}
+}
@@ -2,10 +2,14 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"sort"
+	"strings"
 	"time"
 
+	"github.com/aymanbagabas/go-osc52/v2"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/ssh"
@@ -13,6 +17,7 @@ import (
 	"github.com/muesli/termenv"
 
 	"github.com/ZaneH/defuse.party-tui/internal/client"
+	"github.com/ZaneH/defuse.party-tui/internal/profile"
 	"github.com/ZaneH/defuse.party-tui/internal/styles"
 	"github.com/ZaneH/defuse.party-tui/internal/tui/modules"
 	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
@@ -21,8 +26,10 @@ import (
 type Model struct {
 	state AppState
 
-	grpcAddr     string
+	config       Config
+	dial         func() (client.GameClient, error)
 	gameClient   client.GameClient
+	gameID       int
 	sessionID    string
 	bombs        []*pb.Bomb
 	selectedBomb int
@@ -38,16 +45,27 @@ type Model struct {
 	height int
 	err    error
 
+	// terminal is what the client's terminal can display; every view is
+	// converted for it before it is drawn.
+	terminal styles.Terminal
+
+	// theme is the palette the player chose, swapped into every view.
+	theme styles.Theme
+
 	startedAt        time.Time
+	endedAt          time.Time
 	duration         time.Duration
 	strikeFlashUntil time.Time
 	flashStrike      bool
 
 	showQuitConfirm bool
 
+	missionSections []MissionSection
+
 	menuSelection     int
 	sectionSelection  int
 	missionSelection  int
+	briefingScroll    int
 	freePlaySelection int
 	gameOverSelection int
 
@@ -55,24 +73,95 @@ type Model struct {
 	freePlayCursor    int
 	freePlayInModules bool
 
-	showManualDialog bool
+	manual manualPager
 
 	pendingGameConfig *pb.GameConfig
+
+	loadID             int
+	loadCancel         context.CancelFunc
+	loadStartedAt      time.Time
+	loadOrigin         AppState
+	loadErr            error
+	loadErrorSelection int
+
+	solveOrder   []moduleSolve
+	stats        *gameStats
+	showReport   bool
+	reportScroll int
+
+	lastSyncedAt time.Time
+	syncErr      error
+	syncFailures int
+
+	backendStatus client.Status
+
+	toasts toasts
+
+	profiles      *profile.Store
+	profile       *profile.Profile
+	nicknameInput string
+	nicknameErr   error
+
+	// dailyDate is the Daily Bomb being viewed or played, and dailyRanked
+	// whether the current game is the player's ranked attempt at it.
+	dailyDate      string
+	dailyRanked    bool
+	dailyBoard     []profile.DailyEntry
+	dailyBoardErr  error
+	dailySelection int
+
+	// now is the clock the game runs on. Tests stop it so that timers
+	// render the same every run.
+	now func() time.Time
+
+	// clipboard sets the clipboard of the player's terminal.
+	clipboard func(string) error
 }
 
-func NewProgramHandler(grpcAddr string) bubbletea.ProgramHandler {
+type Config struct {
+	ResyncInterval time.Duration
+
+	// Missions are extra sections from a mission catalog file, merged into
+	// the built-in ones.
+	Missions []MissionSection
+}
+
+// NewProgramHandler starts a program per SSH session. Every session gets its
+// game clients from clients, so they share one backend connection.
+func NewProgramHandler(config Config, clients *client.Manager, profiles *profile.Store) bubbletea.ProgramHandler {
+	// Styles are package-level, so every session draws with lipgloss's
+	// default renderer and its process-wide color profile. Views are rendered
+	// in full color with it, and each session converts its frames for its own
+	// terminal and theme in View. That pass is skipped for true color
+	// terminals on the dark theme, and saves threading a renderer per session
+	// through every style in the UI.
+	lipgloss.SetColorProfile(termenv.TrueColor)
+
 	return func(sess ssh.Session) *tea.Program {
-		_, _, active := sess.Pty()
-		if active {
-			lipgloss.SetColorProfile(termenv.ANSI256)
+		pty, _, _ := sess.Pty()
+
+		m := newModel(config, clients.Client)
+		m.terminal = styles.DetectTerminal(pty.Term, sess.Environ())
+		m.clipboard = func(text string) error {
+			// OSC 52 asks the terminal to set its clipboard. Writes to the
+			// session are whole, so it can't land inside a frame.
+			seq := osc52.New(text)
+			if strings.HasPrefix(pty.Term, "screen") {
+				seq = seq.Screen()
+			}
+			_, err := seq.WriteTo(sess)
+			return err
+		}
+		if profiles != nil {
+			if p, err := profiles.Load(profile.ID(sess.PublicKey(), sess.User())); err != nil {
+				log.Printf("failed to load profile for %s: %v", sess.User(), err)
+			} else {
+				m.attachProfile(profiles, p)
+			}
 		}
 
 		return tea.NewProgram(
-			&Model{
-				state:       StateMainMenu,
-				grpcAddr:    grpcAddr,
-				moduleCache: make(map[string]modules.ModuleModel),
-			},
+			m,
 			tea.WithInput(sess),
 			tea.WithOutput(sess),
 			tea.WithAltScreen(),
@@ -80,65 +169,146 @@ func NewProgramHandler(grpcAddr string) bubbletea.ProgramHandler {
 	}
 }
 
+func newModel(config Config, dial func() (client.GameClient, error)) *Model {
+	return &Model{
+		state:       StateMainMenu,
+		config:      config,
+		dial:        dial,
+		moduleCache: make(map[string]modules.ModuleModel),
+		theme:       styles.Dark,
+
+		missionSections: mergeMissionSections(builtinMissionSections, config.Missions),
+		now:             time.Now,
+	}
+}
+
 func (m *Model) Init() tea.Cmd {
 	return nil
 }
 
+// StartGame shows the loading screen and creates a game from config in the
+// background. The load can be cancelled from the loading screen and retried
+// from the error screen if it fails.
 func (m *Model) StartGame(config *pb.GameConfig) tea.Cmd {
-	return func() tea.Msg {
-		client, err := client.New(m.grpcAddr)
+	m.cancelLoading()
+	if m.state != StateLoading && m.state != StateLoadError {
+		m.loadOrigin = m.state
+	}
+	m.pendingGameConfig = config
+	m.state = StateLoading
+	m.loadID++
+	m.loadStartedAt = m.now()
+	m.loadErr = nil
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.loadCancel = cancel
+
+	loadID := m.loadID
+	dial := m.dial
+	load := func() tea.Msg {
+		client, err := dial()
 		if err != nil {
-			return loadingErrorMsg{err: fmt.Errorf("failed to connect: %w", err)}
+			return loadingErrorMsg{loadID: loadID, err: fmt.Errorf("failed to connect: %w", err)}
 		}
 
-		sessionID, err := client.CreateGame(context.Background(), config)
+		sessionID, err := client.CreateGame(ctx, config)
 		if err != nil {
 			client.Close()
-			return loadingErrorMsg{err: fmt.Errorf("failed to create game: %w", err)}
+			return loadingErrorMsg{loadID: loadID, err: fmt.Errorf("failed to create game: %w", err)}
 		}
 
-		bombs, err := client.GetBombs(context.Background(), sessionID)
+		bombs, err := client.GetBombs(ctx, sessionID)
 		if err != nil {
 			client.Close()
-			return loadingErrorMsg{err: fmt.Errorf("failed to get bombs: %w", err)}
+			return loadingErrorMsg{loadID: loadID, err: fmt.Errorf("failed to get bombs: %w", err)}
 		}
 
 		return gameReadyMsg{
+			loadID:    loadID,
 			client:    client,
 			sessionID: sessionID,
 			bombs:     bombs,
 		}
 	}
+	return tea.Batch(load, m.loadingTick())
 }
 
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case loadingErrorMsg:
-		m.state = StateGameOver
-		m.err = msg.err
-		return m, tea.Quit
+		if msg.loadID != m.loadID || m.state != StateLoading {
+			return m, nil
+		}
+		m.cancelLoading()
+		m.state = StateLoadError
+		m.loadErr = msg.err
+		m.loadErrorSelection = 0
+		return m, nil
+
+	case nicknameSavedMsg:
+		return m, m.handleNicknameSaved(msg)
+
+	case profileUpdatedMsg:
+		return m, m.handleProfileUpdated(msg)
+
+	case dailyBoardMsg:
+		m.handleDailyBoard(msg)
+		return m, nil
+
+	case dailyClaimedMsg:
+		return m, m.handleDailyClaimed(msg)
+
+	case dailyFinishedMsg:
+		return m, m.handleDailyFinished(msg)
+
+	case reportCopiedMsg:
+		return m, m.handleReportCopied(msg)
+
+	case loadingTickMsg:
+		if msg.loadID != m.loadID || m.state != StateLoading {
+			return m, nil
+		}
+		return m, m.loadingTick()
 
 	case gameReadyMsg:
+		if msg.loadID != m.loadID || m.state != StateLoading {
+			// The player cancelled while the game was being created.
+			msg.client.Close()
+			return m, nil
+		}
+		m.cancelLoading()
 		m.state = StateBombSelection
+		m.gameID++
 		m.gameClient = msg.client
 		m.sessionID = msg.sessionID
 		m.bombs = msg.bombs
 		m.selectedBomb = 0
 		m.currentFace = 0
 		m.selectedModule = 0
+		m.solveOrder = nil
+		m.endedAt = time.Time{}
 		if len(msg.bombs) > 0 {
 			bomb := msg.bombs[0]
 			m.startedAt = time.Unix(int64(bomb.GetStartedAt()), 0)
 			m.duration = time.Duration(bomb.GetTimerDuration()) * time.Second
 		}
-		return m, tea.Tick(time.Second, func(t time.Time) tea.Msg {
-			return tickMsg{t: t}
-		})
+		m.stats = newGameStats(m.startedAt)
+		m.lastSyncedAt = m.now()
+		m.syncErr = nil
+		m.syncFailures = 0
+		m.backendStatus = client.Status{}
+		return m, tea.Batch(m.tick(), m.scheduleResync(), m.startBackgroundModules(), m.claimDaily())
+
+	case bombsSyncedMsg:
+		if msg.gameID != m.gameID || !m.inGame() {
+			return m, nil
+		}
+		return m, m.handleBombsSynced(msg)
 
 	case tickMsg:
-		now := time.Now()
+		now := m.now()
 
-		if m.startedAt.IsZero() {
+		if msg.gameID != m.gameID || m.state == StateGameOver || m.startedAt.IsZero() {
 			return m, nil
 		}
 
@@ -146,75 +316,104 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		remaining := m.duration - elapsed
 
 		if remaining <= 0 {
-			m.state = StateGameOver
-			m.err = fmt.Errorf("time's up!")
-			return m, tea.Quit
+			return m, m.endGame(errTimeUp)
 		}
 
 		if m.flashStrike && now.After(m.strikeFlashUntil) {
 			m.flashStrike = false
 		}
+		m.refreshBackendStatus()
 
-		return m, tea.Tick(time.Second, func(t time.Time) tea.Msg {
-			return tickMsg{t: t}
-		})
+		return m, m.tick()
 
 	case modules.ModuleResultMsg:
-		if msg.Err != nil {
+		if !m.inGame() {
 			return m, nil
 		}
+		// The owning module applies the result itself, whether or not it is
+		// still the active one.
+		var cmd tea.Cmd
+		owner, cached := m.moduleCache[msg.ModuleID]
+		if cached {
+			_, cmd = owner.Update(msg)
+		}
+		m.refreshBackendStatus()
+		if msg.Err != nil {
+			return m, tea.Batch(cmd, m.notifyInputError(msg.ModuleID, msg.Err))
+		}
 		result := msg.Result
 		if result.GetStrike() {
+			m.stats.strike(result.GetModuleId())
 			m.flashStrike = true
-			m.strikeFlashUntil = time.Now().Add(500 * time.Millisecond)
+			m.strikeFlashUntil = m.now().Add(500 * time.Millisecond)
 		}
 		if bombStatus := result.GetBombStatus(); bombStatus != nil {
-			bomb := m.getCurrentBomb()
+			bomb := m.bombByID(msg.Input.GetBombId())
+			if bomb == nil {
+				bomb = m.getCurrentBomb()
+			}
 			if bomb != nil {
-				bomb.StrikeCount = bombStatus.GetStrikeCount()
-
-				for _, cachedMod := range m.moduleCache {
-					if cachedMod.ModuleType() == pb.Module_CLOCK {
-						if clockMod, ok := cachedMod.(*modules.ClockModule); ok {
-							clockMod.UpdateStrikes(bomb.StrikeCount)
-						}
-					}
-				}
+				m.setStrikes(bomb, bombStatus.GetStrikeCount())
 			}
 		}
-		if result.GetSolved() && m.activeModule != nil {
-			m.activeModule.UpdateState(&pb.Module{
-				Id:     result.GetModuleId(),
-				Solved: true,
-			})
-		}
 		if result.GetBombStatus().GetExploded() {
-			m.state = StateGameOver
-			m.err = fmt.Errorf("BOOM! The bomb exploded.")
-			return m, tea.Quit
+			return m, tea.Batch(cmd, m.endGame(errExploded))
 		}
-		return m, nil
+		if result.GetSolved() {
+			m.markModuleSolved(result.GetModuleId())
+		}
+		if m.allBombsDefused() {
+			return m, tea.Batch(cmd, m.endGame(nil))
+		}
+		return m, cmd
 
 	case modules.BackToBombMsg:
+		if m.state != StateModuleActive {
+			return m, nil
+		}
+		m.leaveActiveModule()
 		m.state = StateBombView
-		m.activeModule = nil
+		return m, nil
+
+	case modules.TickMsg:
+		if !m.inGame() {
+			return m, nil
+		}
+		mod, exists := m.moduleCache[msg.TargetModuleID()]
+		if !exists {
+			return m, nil
+		}
+		_, cmd := mod.Update(msg)
+		return m, cmd
+
+	case toastExpiredMsg:
+		m.expireToast(msg.id)
 		return m, nil
 
 	case tea.KeyMsg:
-		if m.showManualDialog {
-			switch msg.String() {
-			case "esc":
-				m.showManualDialog = false
-			}
+		msg = m.boundKey(msg)
+		if m.toasts.logOpen {
+			m.handleToastLogKeys(msg.String())
+			return m, nil
+		}
+		if msg.String() == "ctrl+n" {
+			m.toasts.logOpen = true
+			m.toasts.logScroll = 0
+			return m, nil
+		}
+
+		if m.manual.open {
+			return m, m.handleManualKeys(msg)
+		}
+		if msg.String() == "?" && m.state != StateNickname && !m.showQuitConfirm {
+			m.openManual()
 			return m, nil
 		}
 
 		if m.showQuitConfirm {
 			switch msg.String() {
 			case "y", "Y":
-				if m.gameClient != nil {
-					m.gameClient.Close()
-				}
+				m.closeGameClient()
 				return m, tea.Quit
 			case "n", "N", "esc":
 				m.showQuitConfirm = false
@@ -223,10 +422,29 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		if m.state == StateNickname {
+			return m, m.handleNicknameKeys(msg)
+		}
+
 		if cmd, handled := m.handleMenuKeys(msg.String()); handled {
 			return m, cmd
 		}
 
+		if msg.String() == "!" && (m.state == StateBombView || m.state == StateModuleActive) {
+			return m, m.jumpToUrgentNeedy()
+		}
+
+		switch msg.String() {
+		case "pgup":
+			if m.scrollManualPane(-1) {
+				return m, nil
+			}
+		case "pgdown":
+			if m.scrollManualPane(1) {
+				return m, nil
+			}
+		}
+
 		switch m.state {
 		case StateBombSelection:
 			return m.handleBombSelectionKeys(msg)
@@ -239,15 +457,21 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		if msg.String() == "ctrl+c" {
-			if m.gameClient != nil {
-				m.gameClient.Close()
-			}
+			m.closeGameClient()
 			return m, tea.Quit
 		}
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		// The active module lays out to the content box, not the terminal.
+		if m.state == StateModuleActive && m.activeModule != nil {
+			newModel, cmd := m.activeModule.Update(m.moduleSize())
+			if newModule, ok := newModel.(modules.ModuleModel); ok {
+				m.activeModule = newModule
+			}
+			return m, cmd
+		}
 	}
 
 	if m.state == StateModuleActive && m.activeModule != nil {
@@ -269,10 +493,18 @@ func (m *Model) handleMenuKeys(key string) (tea.Cmd, bool) {
 		return m.handleSectionSelectKeys(key)
 	case StateMissionSelect:
 		return m.handleMissionSelectKeys(key)
+	case StateMissionBriefing:
+		return m.handleMissionBriefingKeys(key)
+	case StateDaily:
+		return m.handleDailyKeys(key)
 	case StateFreePlayMenu:
 		return m.handleFreePlayMenuKeys(key)
 	case StateFreePlayAdvanced:
 		return m.handleFreePlayAdvancedKeys(key)
+	case StateLoading:
+		return m.handleLoadingKeys(key)
+	case StateLoadError:
+		return m.handleLoadErrorKeys(key)
 	case StateGameOver:
 		return m.handleGameOverKeys(key)
 	}
@@ -280,6 +512,12 @@ func (m *Model) handleMenuKeys(key string) (tea.Cmd, bool) {
 }
 
 func (m *Model) handleGameOverKeys(key string) (tea.Cmd, bool) {
+	if m.showReport {
+		if cmd, ok := m.handleReportKeys(key); ok {
+			return cmd, true
+		}
+	}
+
 	handled := true
 	switch key {
 	case "up", "k":
@@ -287,28 +525,113 @@ func (m *Model) handleGameOverKeys(key string) (tea.Cmd, bool) {
 			m.gameOverSelection--
 		}
 	case "down", "j":
-		if m.gameOverSelection < 1 {
+		if m.gameOverSelection < len(gameOverOptions)-1 {
 			m.gameOverSelection++
 		}
 	case "enter":
-		if m.gameOverSelection == 0 {
+		switch GameOverOption(m.gameOverSelection) {
+		case GameOverReturnToMenu:
 			m.resetToMainMenu()
-		} else {
-			if m.gameClient != nil {
-				m.gameClient.Close()
-			}
+		case GameOverPlayAgain:
+			return m.replayGame(), true
+		case GameOverQuit:
+			m.closeGameClient()
 			return tea.Quit, true
 		}
+	case "e", "E":
+		m.showReport = !m.showReport
+		m.reportScroll = 0
+	case "esc":
+		if m.showReport {
+			m.showReport = false
+		} else {
+			m.resetToMainMenu()
+		}
 	default:
 		handled = false
 	}
 	return nil, handled
 }
 
+// handleReportKeys scrolls and copies the JSON report.
+func (m *Model) handleReportKeys(key string) (tea.Cmd, bool) {
+	switch key {
+	case "up", "k":
+		m.scrollReport(-1)
+	case "down", "j":
+		m.scrollReport(1)
+	case "pgup":
+		m.scrollReport(-m.reportJSONRows())
+	case "pgdown", " ":
+		m.scrollReport(m.reportJSONRows())
+	case "c", "C":
+		return m.copyReport(), true
+	default:
+		return nil, false
+	}
+	return nil, true
+}
+
+func (m *Model) inGame() bool {
+	switch m.state {
+	case StateBombSelection, StateBombView, StateModuleActive:
+		return true
+	}
+	return false
+}
+
+// The errors a lost game ends with. gameOutcome tells them apart, so wrap
+// rather than replace them.
+var (
+	errExploded = errors.New("BOOM! The bomb exploded.")
+	errTimeUp   = errors.New("time's up!")
+)
+
+// endGame shows the game over screen. err is nil if the bomb was defused.
+func (m *Model) endGame(err error) tea.Cmd {
+	m.state = StateGameOver
+	m.endedAt = m.now()
+	m.leaveActiveModule()
+	m.err = err
+	m.showReport = false
+	m.showQuitConfirm = false
+	m.gameOverSelection = 0
+	return tea.Batch(m.recordGame(), m.finishDaily())
+}
+
+// replayGame starts the last bomb again. A Daily Bomb replays as the one for
+// the day it is now.
+func (m *Model) replayGame() tea.Cmd {
+	config, daily := m.pendingGameConfig, m.dailyDate != ""
+	m.resetToMainMenu()
+	if config == nil {
+		return nil
+	}
+	if daily {
+		return m.startDaily()
+	}
+	return m.StartGame(config)
+}
+
+func (m *Model) closeGameClient() {
+	if m.gameClient != nil {
+		m.gameClient.Close()
+		m.gameClient = nil
+	}
+}
+
+func (m *Model) tick() tea.Cmd {
+	gameID := m.gameID
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return tickMsg{t: t, gameID: gameID}
+	})
+}
+
 func (m *Model) resetToMainMenu() {
+	m.cancelLoading()
+	m.closeGameClient()
 	m.state = StateMainMenu
 	m.menuSelection = 0
-	m.gameClient = nil
 	m.sessionID = ""
 	m.bombs = nil
 	m.selectedBomb = 0
@@ -318,11 +641,22 @@ func (m *Model) resetToMainMenu() {
 	m.moduleCache = make(map[string]modules.ModuleModel)
 	m.err = nil
 	m.startedAt = time.Time{}
+	m.endedAt = time.Time{}
 	m.duration = 0
+	m.solveOrder = nil
+	m.stats = nil
+	m.lastSyncedAt = time.Time{}
+	m.syncErr = nil
+	m.syncFailures = 0
+	m.backendStatus = client.Status{}
+	m.showReport = false
 	m.flashStrike = false
 	m.showQuitConfirm = false
-	m.showManualDialog = false
+	m.manual.open = false
+	m.gameOverSelection = 0
 	m.pendingGameConfig = nil
+	m.dailyDate = ""
+	m.dailyRanked = false
 }
 
 func (m *Model) handleBombSelectionKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
@@ -344,9 +678,7 @@ func (m *Model) handleBombSelectionKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.showQuitConfirm = true
 		return m, nil
 	case "ctrl+c":
-		if m.gameClient != nil {
-			m.gameClient.Close()
-		}
+		m.closeGameClient()
 		return m, tea.Quit
 	}
 	return m, nil
@@ -359,45 +691,14 @@ func (m *Model) handleBombViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
 		moduleIdx := int(msg.String()[0] - '1')
 		if moduleIdx >= 0 && moduleIdx < len(faceModules) {
-			m.state = StateModuleActive
-			mod := faceModules[moduleIdx]
-			moduleID := mod.GetId()
-
-			if cached, exists := m.moduleCache[moduleID]; exists {
-				m.activeModule = cached
-				return m, nil
-			} else {
-				if mod.GetType() == pb.Module_CLOCK {
-					m.activeModule = m.createClockModule(mod)
-				} else {
-					m.activeModule = modules.NewModule(mod, m.gameClient, m.sessionID, m.getCurrentBomb().GetId())
-				}
-				m.moduleCache[moduleID] = m.activeModule
-				return m, m.activeModule.Init()
-			}
+			return m, m.openModule(faceModules[moduleIdx])
 		}
 	case "enter":
 		if len(faceModules) > 0 {
-			m.state = StateModuleActive
-			mod := faceModules[m.selectedModule]
-			moduleID := mod.GetId()
-
-			if cached, exists := m.moduleCache[moduleID]; exists {
-				m.activeModule = cached
-				return m, nil
-			} else {
-				if mod.GetType() == pb.Module_CLOCK {
-					m.activeModule = m.createClockModule(mod)
-				} else {
-					m.activeModule = modules.NewModule(mod, m.gameClient, m.sessionID, m.getCurrentBomb().GetId())
-				}
-				m.moduleCache[moduleID] = m.activeModule
-				return m, m.activeModule.Init()
-			}
+			return m, m.openModule(faceModules[m.selectedModule])
 		}
 	case "esc", "tab", "b":
 		m.state = StateBombSelection
-		m.moduleCache = make(map[string]modules.ModuleModel)
 		return m, nil
 	case "<":
 		if m.currentFace > 0 {
@@ -430,14 +731,65 @@ func (m *Model) handleBombViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.showQuitConfirm = true
 		return m, nil
 	case "ctrl+c":
-		if m.gameClient != nil {
-			m.gameClient.Close()
-		}
+		m.closeGameClient()
 		return m, tea.Quit
 	}
 	return m, nil
 }
 
+func (m *Model) openModule(mod *pb.Module) tea.Cmd {
+	m.leaveActiveModule()
+	m.state = StateModuleActive
+	m.stats.moduleOpened(m.selectedBomb, mod, m.now())
+
+	module, initCmd := m.loadModule(m.getCurrentBomb(), mod)
+	module.Update(m.moduleSize())
+	m.activeModule = module
+	m.manual.paneScroll = 0
+	return tea.Batch(initCmd, module.OnEnter())
+}
+
+// loadModule returns the cached model for mod, creating and initialising it
+// on first use.
+func (m *Model) loadModule(bomb *pb.Bomb, mod *pb.Module) (modules.ModuleModel, tea.Cmd) {
+	if cached, exists := m.moduleCache[mod.GetId()]; exists {
+		return cached, nil
+	}
+
+	var module modules.ModuleModel
+	if mod.GetType() == pb.Module_CLOCK {
+		module = m.createClockModule(bomb, mod)
+	} else {
+		module = modules.NewModule(mod, m.gameClient, m.sessionID, bomb.GetId())
+	}
+	m.moduleCache[mod.GetId()] = module
+	return module, module.Init()
+}
+
+func (m *Model) leaveActiveModule() {
+	if m.activeModule == nil {
+		return
+	}
+	m.stats.moduleClosed(m.now())
+	m.activeModule.OnLeave()
+	m.activeModule = nil
+}
+
+// startBackgroundModules creates the needy modules up front so their
+// countdowns tick even if the player never opens them.
+func (m *Model) startBackgroundModules() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, bomb := range m.bombs {
+		for _, mod := range bomb.GetModules() {
+			if isNeedyModule(mod.GetType()) {
+				_, cmd := m.loadModule(bomb, mod)
+				cmds = append(cmds, cmd)
+			}
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
 func (m *Model) getCurrentBomb() *pb.Bomb {
 	if m.selectedBomb >= 0 && m.selectedBomb < len(m.bombs) {
 		return m.bombs[m.selectedBomb]
@@ -445,17 +797,18 @@ func (m *Model) getCurrentBomb() *pb.Bomb {
 	return nil
 }
 
-func (m *Model) createClockModule(mod *pb.Module) modules.ModuleModel {
-	bomb := m.getCurrentBomb()
+func (m *Model) createClockModule(bomb *pb.Bomb, mod *pb.Module) modules.ModuleModel {
 	if bomb == nil {
 		return modules.NewUnimplementedModule(mod)
 	}
 	return modules.NewClockModule(
 		mod,
+		m.now,
 		m.startedAt,
 		m.duration,
 		bomb.GetStrikeCount(),
 		bomb.GetMaxStrikes(),
+		m.terminal.ASCII,
 	)
 }
 
@@ -519,21 +872,37 @@ func (m *Model) maxFaceIndex() int {
 }
 
 func (m *Model) View() string {
+	return m.terminal.Render(m.theme.Recolor(m.view()))
+}
+
+func (m *Model) view() string {
+	if layout := m.layout(); layout.TooSmall() {
+		return layout.TooSmallView()
+	}
+
 	var view string
 
 	switch m.state {
 	case StateMainMenu:
 		view = m.mainMenuView()
+	case StateNickname:
+		view = m.nicknameView()
 	case StateSectionSelect:
 		view = m.sectionSelectView()
 	case StateMissionSelect:
 		view = m.missionSelectView()
+	case StateMissionBriefing:
+		view = m.missionBriefingView()
+	case StateDaily:
+		view = m.dailyView()
 	case StateFreePlayMenu:
 		view = m.freePlayMenuView()
 	case StateFreePlayAdvanced:
 		view = m.freePlayAdvancedView()
 	case StateLoading:
 		view = m.loadingView()
+	case StateLoadError:
+		view = m.loadErrorView()
 	case StateGameOver:
 		view = m.gameOverView()
 	case StateBombSelection:
@@ -542,40 +911,22 @@ func (m *Model) View() string {
 		view = m.bombView()
 	case StateModuleActive:
 		if m.activeModule != nil {
-			header := m.renderHeader(time.Now())
+			header := m.renderHeader(m.now())
 			footer := m.renderFooter()
 			content := m.activeModule.View()
-			view = lipgloss.JoinVertical(
+			view = m.withManualPane(lipgloss.JoinVertical(
 				lipgloss.Top,
 				header,
-				styles.ContentBox.Render(content),
+				m.layout().Content().Render(content),
 				footer,
-			)
+			))
 		} else {
 			view = m.errorView()
 		}
 	}
 
-	if m.showManualDialog {
-		dialog := styles.DialogBox.Render(
-			lipgloss.JoinVertical(
-				lipgloss.Center,
-				styles.Title.Render("MANUAL"),
-				"",
-				styles.Subtitle.Render("Open this URL in your browser:"),
-				"",
-				styles.Active.Render(hyperlink("https://bombmanual.com/", "https://bombmanual.com/")),
-				"",
-				styles.Help.Render("(Click the link or copy to your browser)"),
-				"",
-				styles.Help.Render("[ESC] Back"),
-			),
-		)
-		view = lipgloss.Place(
-			m.width, m.height,
-			lipgloss.Center, lipgloss.Center,
-			dialog,
-		)
+	if m.manual.open {
+		view = m.manualView()
 	}
 
 	if m.showQuitConfirm {
@@ -594,19 +945,11 @@ func (m *Model) View() string {
 		)
 	}
 
-	return view
-}
+	if m.toasts.logOpen {
+		view = m.toastLogView()
+	}
 
-func (m *Model) loadingView() string {
-	return styles.Center(
-		lipgloss.JoinVertical(
-			lipgloss.Center,
-			styles.Title.Render("DEFUSE.PARTY"),
-			"",
-			styles.Subtitle.Render("Creating game..."),
-		),
-		m.width, m.height,
-	)
+	return m.overlayToasts(view)
 }
 
 func (m *Model) errorView() string {
@@ -0,0 +1,520 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ZaneH/defuse.party-tui/internal/client"
+	"github.com/ZaneH/defuse.party-tui/internal/client/clienttest"
+	"github.com/ZaneH/defuse.party-tui/internal/custombomb"
+	"github.com/ZaneH/defuse.party-tui/internal/profile"
+	"github.com/ZaneH/defuse.party-tui/internal/tui/tuitest"
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+// testNow is the time on the stopped clock test models run by. Test bombs
+// start then, so their timers always read 05:00.
+var testNow = time.Date(2026, 10, 16, 23, 30, 0, 0, time.UTC)
+
+func testBomb() *pb.Bomb {
+	return &pb.Bomb{
+		Id:            "bomb-1",
+		SerialNumber:  "AB3CD7",
+		TimerDuration: 300,
+		StartedAt:     int32(testNow.Unix()),
+		MaxStrikes:    3,
+		Batteries:     2,
+		Ports:         []pb.Port{pb.Port_RJ45},
+		Modules: map[string]*pb.Module{
+			"clock": {
+				Id:       "clock",
+				Type:     pb.Module_CLOCK,
+				Position: &pb.ModulePosition{Face: 0, Row: 0, Col: 0},
+			},
+			"wires": {
+				Id:       "wires",
+				Type:     pb.Module_WIRES,
+				Position: &pb.ModulePosition{Face: 0, Row: 0, Col: 1},
+				State: &pb.Module_WiresState{WiresState: &pb.WiresState{Wires: []*pb.Wire{
+					{WireColor: pb.Color_RED, Position: 1},
+					{WireColor: pb.Color_BLUE, Position: 2},
+					{WireColor: pb.Color_YELLOW, Position: 3},
+				}}},
+			},
+		},
+	}
+}
+
+func newTestModel(c *clienttest.Client) *Model {
+	m := newModel(Config{}, func() (client.GameClient, error) {
+		return c, nil
+	})
+	m.now = func() time.Time { return testNow }
+	return m
+}
+
+func newTestDriver(t *testing.T, c *clienttest.Client) *tuitest.Driver {
+	return tuitest.NewDriver(t, newTestModel(c)).Send(tea.WindowSizeMsg{Width: 80, Height: 40})
+}
+
+func TestMainMenu(t *testing.T) {
+	d := newTestDriver(t, clienttest.New())
+
+	d.Snapshot("main menu").
+		Type("down").Snapshot("free play highlighted").
+		Type("enter").Snapshot("free play menu").
+		Type("esc").Snapshot("back to main menu").
+		RequireGolden()
+
+	d.Type("q")
+	if !d.Quit() {
+		t.Error("q on the main menu did not quit")
+	}
+}
+
+func TestDefuseBomb(t *testing.T) {
+	c := clienttest.New(testBomb())
+	c.QueueResult(
+		&pb.PlayerInputResult{ModuleId: "wires", Strike: true, BombStatus: &pb.BombStatus{StrikeCount: 1, MaxStrikes: 3}},
+		&pb.PlayerInputResult{ModuleId: "wires", Solved: true, BombStatus: &pb.BombStatus{StrikeCount: 1, MaxStrikes: 3}},
+	)
+	d := newTestDriver(t, c)
+
+	d.Type("down", "enter", "enter").Snapshot("bomb selection").
+		Type("enter").Snapshot("bomb view").
+		Type("2").Snapshot("wires module").
+		Type("3").Snapshot("strike").
+		Type("1").Snapshot("defused").
+		RequireGolden()
+
+	m := d.Model().(*Model)
+	if m.state != StateGameOver || m.err != nil {
+		t.Fatalf("state = %v, err = %v; want a won game", m.state, m.err)
+	}
+	if configs := c.Configs(); len(configs) != 1 || configs[0].GetLevel().GetLevel() != 1 {
+		t.Errorf("CreateGame configs = %v", configs)
+	}
+	if inputs := c.Inputs(); len(inputs) != 2 {
+		t.Errorf("sent %d inputs, want 2", len(inputs))
+	}
+}
+
+func TestBombExplodes(t *testing.T) {
+	c := clienttest.New(testBomb())
+	c.QueueResult(&pb.PlayerInputResult{
+		ModuleId:   "wires",
+		Strike:     true,
+		BombStatus: &pb.BombStatus{StrikeCount: 3, MaxStrikes: 3, Exploded: true},
+	})
+	d := newTestDriver(t, c)
+
+	d.Type("down", "enter", "enter", "enter", "2", "1").Snapshot("exploded").
+		Type("esc").Snapshot("back to main menu").
+		RequireGolden()
+
+	if !c.Closed() {
+		t.Error("game client was not closed when returning to the menu")
+	}
+}
+
+func TestLoadingError(t *testing.T) {
+	c := clienttest.New(testBomb())
+	c.CreateErr = status.Error(codes.Unavailable, "connection refused")
+	d := newTestDriver(t, c)
+
+	d.Type("down", "enter", "enter").Snapshot("loading failed").
+		Type("down").Snapshot("back highlighted").
+		Type("up").RequireGolden()
+
+	c.CreateErr = nil
+	d.Type("enter")
+	m := d.Model().(*Model)
+	if m.state != StateBombSelection {
+		t.Fatalf("state after retry = %v, want bomb selection", m.state)
+	}
+	if configs := c.Configs(); len(configs) != 2 || configs[1].GetLevel().GetLevel() != 1 {
+		t.Errorf("retry did not reuse the pending config: %v", configs)
+	}
+}
+
+func TestLoadingErrorBack(t *testing.T) {
+	c := clienttest.New()
+	c.CreateErr = errors.New("backend unavailable")
+	d := newTestDriver(t, c)
+
+	d.Type("down", "enter", "enter", "esc")
+	if m := d.Model().(*Model); m.state != StateFreePlayMenu {
+		t.Errorf("state after back = %v, want the free play menu", m.state)
+	}
+}
+
+func TestFreePlayAdvanced(t *testing.T) {
+	c := clienttest.New(testBomb())
+	d := newTestDriver(t, c)
+
+	d.Type("down", "enter", "down", "down", "down", "down", "enter").
+		Type("down", "down", "down", "down").Snapshot("wires selected").
+		Type("right", "right", "right", "right").Snapshot("over capacity")
+
+	// Starting is refused until the bomb fits.
+	d.Type("down", "down", "down", "down", "down", "down", "down", "down", "down", "down", "down", "enter")
+	if configs := c.Configs(); len(configs) != 0 {
+		t.Fatalf("started an invalid bomb: %v", configs)
+	}
+
+	d.Type("up", "up", "up", "up", "up", "up", "up", "up", "up", "up", "up", "left", "left").Snapshot("fits").RequireGolden()
+	d.Type("down", "down", "down", "down", "down", "down", "down", "down", "down", "down", "down", "enter")
+
+	configs := c.Configs()
+	if len(configs) != 1 {
+		t.Fatalf("got %d games, want 1", len(configs))
+	}
+	custom := configs[0].GetCustom()
+	counts := map[pb.Module_ModuleType]int32{}
+	for _, spec := range custom.GetModules() {
+		counts[spec.GetType()] = spec.GetCount()
+	}
+	if counts[pb.Module_WIRES] != 3 || counts[pb.Module_MAZE] != 1 || len(counts) != 9 {
+		t.Errorf("module counts = %v, want 3 wires and one of each other regular module", counts)
+	}
+	if custom.GetNumFaces() != 2 || custom.GetRows() != 2 || custom.GetColumns() != 3 {
+		t.Errorf("faces = %d of %dx%d, want 2 of 2x3", custom.GetNumFaces(), custom.GetRows(), custom.GetColumns())
+	}
+	// The clock takes the last of the 12 slots.
+	if custom.GetMinModules() != 12 {
+		t.Errorf("min modules = %d, want 12", custom.GetMinModules())
+	}
+}
+
+// Every bomb the free play screen lets through must be one the backend builds.
+func TestFreePlayValidate(t *testing.T) {
+	accepted := 0
+	for faces := 1; faces <= 6; faces++ {
+		for _, grid := range faceGrids {
+			for total := 1; total <= custombomb.MaxModules+5; total++ {
+				config := DefaultFreePlayConfig()
+				config.NumFaces, config.Rows, config.Columns = faces, grid[0], grid[1]
+				config.ModuleCounts = map[pb.Module_ModuleType]int{}
+				for i, left := 0, total; left > 0; i++ {
+					count := min(left, maxModuleCount)
+					config.ModuleCounts[freePlayModuleTypes[i]] = count
+					left -= count
+				}
+
+				if config.Validate() != nil {
+					continue
+				}
+				accepted++
+				if err := custombomb.Validate(config.customBomb()); err != nil {
+					t.Errorf("%d faces of %dx%d with %d modules: the backend would refuse the bomb: %v", faces, grid[0], grid[1], total, err)
+				}
+			}
+		}
+	}
+	if accepted == 0 {
+		t.Fatal("no config was accepted")
+	}
+
+	config := DefaultFreePlayConfig()
+	config.NumFaces, config.Rows, config.Columns = 6, 4, 5
+	config.ModuleCounts = map[pb.Module_ModuleType]int{pb.Module_WIRES: 12, pb.Module_MAZE: 12, pb.Module_KEYPAD: 12, pb.Module_MEMORY: 12, pb.Module_SIMON: 12}
+	if err := config.Validate(); err == nil || !strings.Contains(err.Error(), "at most 59 modules besides the clock; remove 1") {
+		t.Errorf("Validate with 60 modules = %v, want the backend's total limit", err)
+	}
+}
+
+func TestMissionBriefing(t *testing.T) {
+	c := clienttest.New(testBomb())
+	d := newTestDriver(t, c)
+
+	d.Type("enter", "down", "down", "down", "enter", "down", "down", "down", "enter").Snapshot("briefing")
+	if configs := c.Configs(); len(configs) != 0 {
+		t.Fatalf("briefing started a game: %v", configs)
+	}
+
+	d.Type("esc").Snapshot("back to missions").
+		Type("enter", "enter").RequireGolden()
+
+	configs := c.Configs()
+	if len(configs) != 1 || configs[0].GetPreset().GetMission() != pb.Mission_MULTI_TASKER {
+		t.Errorf("CreateGame configs = %v, want the Multitasker preset", configs)
+	}
+}
+
+func TestLoadingCancel(t *testing.T) {
+	c := clienttest.New(testBomb())
+	d := newTestDriver(t, c)
+	m := d.Model().(*Model)
+
+	// Start the load without running it, as if the backend were slow.
+	load := m.StartGame(&pb.GameConfig{})
+	d.Snapshot("loading").
+		Type("esc").Snapshot("cancelled").
+		RequireGolden()
+
+	d.Send(load())
+	if m.state != StateMainMenu {
+		t.Errorf("state after a late result = %v, want the main menu", m.state)
+	}
+	if !c.Closed() {
+		t.Error("client from the cancelled load was not closed")
+	}
+}
+
+func TestInputErrorToasts(t *testing.T) {
+	c := clienttest.New(testBomb())
+	c.QueueError(status.Error(codes.Unavailable, "connection refused"))
+	c.QueueResult(&pb.PlayerInputResult{ModuleId: "wires"})
+	d := newTestDriver(t, c)
+
+	d.Type("down", "enter", "enter", "enter", "2", "1").Snapshot("rpc failed").
+		Type("2").Snapshot("next input delivered").
+		Type("2").Snapshot("wire already cut").
+		Type("ctrl+n").Snapshot("notification log").
+		Type("esc").Snapshot("log closed").
+		RequireGolden()
+
+	m := d.Model().(*Model)
+	m.expireToast(m.toasts.active[0].id)
+	if len(m.toasts.active) != 1 || len(m.toasts.history) != 2 {
+		t.Errorf("after expiry: %d active, %d in history; want 1 and 2", len(m.toasts.active), len(m.toasts.history))
+	}
+}
+
+func TestNicknamePrompt(t *testing.T) {
+	store, err := profile.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	p, err := store.Load("user:ada")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := newTestModel(clienttest.New())
+	m.attachProfile(store, p)
+	d := tuitest.NewDriver(t, m).Send(tea.WindowSizeMsg{Width: 80, Height: 40})
+
+	d.Snapshot("first connect").
+		Type("/", "enter").Snapshot("invalid").
+		Type("backspace", "A", "d", "a", "enter").Snapshot("main menu").
+		RequireGolden()
+
+	if m.state != StateMainMenu {
+		t.Fatalf("state = %v, want the main menu", m.state)
+	}
+	if saved, err := store.Load("user:ada"); err != nil || saved.Nickname != "Ada" {
+		t.Errorf("stored profile = %+v, %v; want nickname Ada", saved, err)
+	}
+}
+
+func TestGameHistory(t *testing.T) {
+	store, err := profile.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	if _, err := store.SetNickname("user:ada", "Ada"); err != nil {
+		t.Fatal(err)
+	}
+	p, err := store.Load("user:ada")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := clienttest.New(testBomb())
+	c.QueueResult(&pb.PlayerInputResult{ModuleId: "wires", Solved: true, BombStatus: &pb.BombStatus{StrikeCount: 0, MaxStrikes: 3}})
+	m := newTestModel(c)
+	m.attachProfile(store, p)
+	d := tuitest.NewDriver(t, m).Send(tea.WindowSizeMsg{Width: 80, Height: 40})
+	if m.state != StateMainMenu {
+		t.Fatalf("named player was prompted again: state = %v", m.state)
+	}
+
+	d.Type("down", "enter", "enter", "enter", "2", "1")
+	if m.state != StateGameOver {
+		t.Fatalf("state = %v, want game over", m.state)
+	}
+
+	p, err = store.Load("user:ada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.History) != 1 {
+		t.Fatalf("history = %+v, want one game", p.History)
+	}
+	if game := p.History[0]; game.Mode != "level 1" || game.Outcome != "defused" || game.Modules != 1 || game.Solved != 1 {
+		t.Errorf("recorded game = %+v", game)
+	}
+}
+
+func TestGameOutcome(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, "defused"},
+		{errExploded, "exploded"},
+		{fmt.Errorf("bomb 2: %w", errExploded), "exploded"},
+		{errTimeUp, "timeout"},
+		{errors.New("BOOM! The bomb exploded."), "error"},
+	}
+	for _, tt := range tests {
+		m := newTestModel(clienttest.New())
+		m.err = tt.err
+		if got := m.gameOutcome(); got != tt.want {
+			t.Errorf("gameOutcome(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestReportJSON(t *testing.T) {
+	c := clienttest.New(testBomb())
+	c.QueueResult(&pb.PlayerInputResult{ModuleId: "wires", Solved: true, BombStatus: &pb.BombStatus{MaxStrikes: 3}})
+	m := newTestModel(c)
+	var copied string
+	m.clipboard = func(text string) error {
+		copied = text
+		return nil
+	}
+	d := tuitest.NewDriver(t, m).Send(tea.WindowSizeMsg{Width: 40, Height: 20})
+
+	d.Type("down", "enter", "enter", "enter", "2", "1", "e")
+	top := m.View()
+	if lines := strings.Count(top, "\n") + 1; lines > 20 {
+		t.Fatalf("report is %d rows tall, want at most 20:\n%s", lines, top)
+	}
+	if !strings.Contains(top, "lines 1-") {
+		t.Errorf("report doesn't show its position:\n%s", top)
+	}
+
+	d.Type("pgdown", "pgdown", "pgdown")
+	if bottom := m.View(); bottom == top || !strings.Contains(bottom, "  ]") {
+		t.Errorf("report didn't scroll to its end:\n%s", bottom)
+	}
+
+	d.Type("c")
+	if copied != m.reportJSON() {
+		t.Errorf("copied %q, want the JSON report", copied)
+	}
+	if !strings.Contains(m.View(), "Copied the JSON report") {
+		t.Errorf("copy wasn't confirmed:\n%s", m.View())
+	}
+}
+
+func TestSetStrikes(t *testing.T) {
+	first, second := testBomb(), testBomb()
+	second.Id = "bomb-2"
+	second.Modules = map[string]*pb.Module{
+		"clock-2": {Id: "clock-2", Type: pb.Module_CLOCK, Position: &pb.ModulePosition{}},
+	}
+	m := newTestModel(clienttest.New(first, second))
+	m.bombs = []*pb.Bomb{first, second}
+	m.startedAt, m.duration = testNow, 5*time.Minute
+	m.loadModule(first, first.Modules["clock"])
+	m.loadModule(second, second.Modules["clock-2"])
+
+	m.setStrikes(first, 2)
+	if first.StrikeCount != 2 || second.StrikeCount != 0 {
+		t.Fatalf("strikes = %d and %d, want 2 and 0", first.StrikeCount, second.StrikeCount)
+	}
+	if view := m.moduleCache["clock"].View(); !strings.Contains(view, "[X] [X] [ ]") {
+		t.Errorf("first bomb's clock doesn't show its strikes:\n%s", view)
+	}
+	if view := m.moduleCache["clock-2"].View(); !strings.Contains(view, "[ ] [ ] [ ]") {
+		t.Errorf("second bomb's clock shows the first bomb's strikes:\n%s", view)
+	}
+}
+
+func TestKeybindings(t *testing.T) {
+	store, err := profile.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	if _, err := store.SetNickname("user:ada", "Ada"); err != nil {
+		t.Fatal(err)
+	}
+	p, err := store.SetKeybindings("user:ada", map[string]string{"s": "down", "w": "up"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := newTestModel(clienttest.New(testBomb()))
+	m.attachProfile(store, p)
+	d := tuitest.NewDriver(t, m).Send(tea.WindowSizeMsg{Width: 80, Height: 40})
+	d.Type("s", "s", "w")
+	if m.menuSelection != 1 {
+		t.Errorf("menu selection = %d, want 1 after down, down, up", m.menuSelection)
+	}
+
+	// Search terms are typed as pressed.
+	d.Type("?", "/", "s")
+	if m.manual.query != "s" {
+		t.Errorf("manual search = %q, want s", m.manual.query)
+	}
+}
+
+func TestCampaignProgress(t *testing.T) {
+	store, err := profile.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	if _, err := store.SetNickname("user:ada", "Ada"); err != nil {
+		t.Fatal(err)
+	}
+	first := profile.GameRecord{Mission: "THE_FIRST_BOMB", Outcome: "defused", Duration: 83 * time.Second, Strikes: 1}
+	if _, err := store.RecordGame("user:ada", first); err != nil {
+		t.Fatal(err)
+	}
+	p, err := store.Load("user:ada")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := newTestModel(clienttest.New())
+	m.attachProfile(store, p)
+	d := tuitest.NewDriver(t, m).Send(tea.WindowSizeMsg{Width: 80, Height: 40})
+
+	d.Type("enter").Snapshot("sections").
+		Type("c").Snapshot("campaign").
+		Type("down", "down", "enter")
+	if m.state != StateSectionSelect || len(m.toasts.active) != 1 {
+		t.Errorf("entered a locked section: state = %v, toasts = %v", m.state, m.toasts.active)
+	}
+
+	d.Type("up", "up", "enter").Snapshot("missions").
+		Type("enter").Snapshot("briefing").
+		RequireGolden()
+
+	saved, err := store.Load("user:ada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !saved.Prefs.Campaign {
+		t.Error("campaign mode was not saved")
+	}
+}
+
+func TestQuitConfirm(t *testing.T) {
+	d := newTestDriver(t, clienttest.New(testBomb()))
+
+	d.Type("down", "enter", "enter", "enter", "q").Snapshot("quit confirm").
+		Type("n").Snapshot("dismissed").
+		RequireGolden()
+
+	d.Type("q", "y")
+	if !d.Quit() {
+		t.Error("confirming quit did not quit")
+	}
+}
@@ -0,0 +1,282 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ZaneH/defuse.party-tui/internal/custombomb"
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+// catalogFields names the catalog keys that set CustomBombConfig fields
+// under another name, so the backend's problems point at the right key.
+var catalogFields = map[string]string{
+	"num_faces":            "faces",
+	"min_modules":          "modules",
+	"max_modules_per_face": "modules_per_face",
+}
+
+var catalogIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// catalogFile is the on-disk form of an extra mission catalog. JSON is valid
+// YAML, so either format is accepted.
+type catalogFile struct {
+	Sections []struct {
+		Name     string           `yaml:"name"`
+		Missions []catalogMission `yaml:"missions"`
+	} `yaml:"sections"`
+}
+
+type catalogMission struct {
+	ID          string         `yaml:"id"`
+	Name        string         `yaml:"name"`
+	Description string         `yaml:"description"`
+	Preset      string         `yaml:"preset"`
+	Custom      *catalogCustom `yaml:"custom"`
+}
+
+type catalogCustom struct {
+	Timer          time.Duration   `yaml:"timer"`
+	MaxStrikes     int             `yaml:"max_strikes"`
+	Faces          int             `yaml:"faces"`
+	Rows           int             `yaml:"rows"`
+	Columns        int             `yaml:"columns"`
+	ModulesPerFace int             `yaml:"modules_per_face"`
+	Modules        []catalogModule `yaml:"modules"`
+}
+
+type catalogModule struct {
+	Type  string   `yaml:"type"`
+	OneOf []string `yaml:"one_of"`
+	Count int      `yaml:"count"`
+}
+
+// LoadMissionCatalog reads extra mission sections from path. Missions either
+// reuse a built-in preset, optionally renamed, or fully describe a custom
+// bomb. Every problem in the file is reported, not just the first.
+func LoadMissionCatalog(path string) ([]MissionSection, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open mission catalog: %w", err)
+	}
+	defer f.Close()
+
+	var file catalogFile
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	var errs []error
+	fail := func(where, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: %s: %s", path, where, fmt.Sprintf(format, args...)))
+	}
+
+	if len(file.Sections) == 0 {
+		fail("sections", "no sections defined")
+	}
+
+	ids := make(map[string]string)
+	var sections []MissionSection
+	for i, s := range file.Sections {
+		where := fmt.Sprintf("sections[%d]", i)
+		if s.Name == "" {
+			fail(where, "name is required")
+		}
+		if len(s.Missions) == 0 {
+			fail(where, "no missions defined")
+		}
+
+		section := MissionSection{Name: s.Name}
+		for j, cm := range s.Missions {
+			where := fmt.Sprintf("sections[%d].missions[%d]", i, j)
+			if cm.ID != "" {
+				where += " (" + cm.ID + ")"
+			}
+
+			mission, problems := cm.missionInfo()
+			for _, problem := range problems {
+				fail(where, "%s", problem)
+			}
+			if cm.ID != "" {
+				if other, dup := ids[cm.ID]; dup {
+					fail(where, "id %q is already used by %s", cm.ID, other)
+				}
+				ids[cm.ID] = where
+			}
+			section.Missions = append(section.Missions, mission)
+		}
+		sections = append(sections, section)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return sections, nil
+}
+
+func (cm catalogMission) missionInfo() (MissionInfo, []string) {
+	var problems []string
+	if cm.Name == "" && cm.Preset == "" {
+		problems = append(problems, "name is required")
+	}
+	if cm.ID != "" && !catalogIDPattern.MatchString(cm.ID) {
+		problems = append(problems, fmt.Sprintf("id %q may only contain lowercase letters, digits and '-'", cm.ID))
+	}
+
+	switch {
+	case cm.Preset != "" && cm.Custom != nil:
+		return MissionInfo{}, append(problems, "set either preset or custom, not both")
+	case cm.Preset != "":
+		mission, ok := builtinMission(cm.Preset)
+		if !ok {
+			return MissionInfo{}, append(problems, fmt.Sprintf("unknown preset %q", cm.Preset))
+		}
+		if cm.Name != "" {
+			mission.Name = cm.Name
+		}
+		if cm.Description != "" {
+			mission.Description = cm.Description
+		}
+		return mission, problems
+	case cm.Custom != nil:
+		if cm.ID == "" {
+			problems = append(problems, "id is required for custom missions")
+		}
+		mission := MissionInfo{ID: cm.ID, Name: cm.Name, Description: cm.Description}
+		return mission, append(problems, cm.Custom.fill(&mission)...)
+	}
+	return MissionInfo{}, append(problems, "set preset or custom")
+}
+
+// fill sets up mission to launch the custom bomb c, returning anything that
+// the backend would reject.
+func (c *catalogCustom) fill(mission *MissionInfo) []string {
+	var problems []string
+	problem := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	// Faces fill up to the backend's per-face limit unless told otherwise.
+	perFace := c.ModulesPerFace
+	if perFace == 0 {
+		perFace = custombomb.PerFace(c.Rows, c.Columns)
+	}
+	config := &pb.CustomBombConfig{
+		TimerSeconds:      int32Field(int(c.Timer / time.Second)),
+		MaxStrikes:        int32Field(c.MaxStrikes),
+		NumFaces:          int32Field(c.Faces),
+		Rows:              int32Field(c.Rows),
+		Columns:           int32Field(c.Columns),
+		MaxModulesPerFace: int32Field(perFace),
+	}
+
+	total, solvable := 0, 0
+	for i, cm := range c.Modules {
+		if cm.Count < 1 {
+			problem("custom.modules[%d].count must be at least 1", i)
+		}
+		names := cm.OneOf
+		if cm.Type != "" {
+			names = append([]string{cm.Type}, names...)
+		}
+		if len(names) == 0 || (cm.Type != "" && len(cm.OneOf) > 0) {
+			problem("custom.modules[%d] needs exactly one of type or one_of", i)
+			continue
+		}
+
+		spec := &pb.ModuleSpec{Count: int32(cm.Count)}
+		for _, name := range names {
+			t, ok := pb.Module_ModuleType_value[name]
+			if !ok || pb.Module_ModuleType(t) == pb.Module_UNKNOWN || pb.Module_ModuleType(t) == pb.Module_CLOCK {
+				problem("custom.modules[%d]: unknown module type %q", i, name)
+				continue
+			}
+			// Newer protos name modules the backend can't build yet.
+			if !playableModule(pb.Module_ModuleType(t)) {
+				problem("custom.modules[%d]: module type %q can't be played yet", i, name)
+				continue
+			}
+			spec.PossibleTypes = append(spec.PossibleTypes, pb.Module_ModuleType(t))
+		}
+		if len(spec.PossibleTypes) == 1 {
+			spec.Type, spec.PossibleTypes = spec.PossibleTypes[0], nil
+		}
+		config.Modules = append(config.Modules, spec)
+
+		group := MissionModule{Types: names, Count: cm.Count}
+		mission.Modules = append(mission.Modules, group)
+		total += cm.Count
+		if !group.needy() {
+			solvable += cm.Count
+		}
+	}
+
+	if solvable == 0 {
+		problem("custom.modules must include at least one module that can be solved")
+	}
+	// The backend places the clock in one of the slots as well.
+	config.MinModules = int32Field(total + 1)
+	for _, p := range custombomb.Problems(config) {
+		field, ok := catalogFields[p.Field]
+		if !ok {
+			field = p.Field
+		}
+		problem("custom.%s: %s", field, p.Reason)
+	}
+
+	mission.Custom = config
+	mission.Timer = c.Timer
+	mission.MaxStrikes = c.MaxStrikes
+	mission.Faces = c.Faces
+	return problems
+}
+
+func builtinMission(name string) (MissionInfo, bool) {
+	v, ok := pb.Mission_value[name]
+	if !ok {
+		return MissionInfo{}, false
+	}
+	for _, section := range builtinMissionSections {
+		for _, mission := range section.Missions {
+			if mission.Mission == pb.Mission(v) {
+				return mission, true
+			}
+		}
+	}
+	return MissionInfo{}, false
+}
+
+// int32Field narrows a catalog number for the backend, keeping numbers too
+// big for an int32 out of range instead of letting them wrap into it.
+func int32Field(n int) int32 {
+	return int32(min(max(n, math.MinInt32), math.MaxInt32))
+}
+
+// mergeMissionSections adds extra missions to the built-in sections. Missions
+// in a section named like a built-in one join it; other sections follow the
+// built-in ones.
+func mergeMissionSections(builtin, extra []MissionSection) []MissionSection {
+	merged := make([]MissionSection, len(builtin))
+	index := make(map[string]int)
+	for i, section := range builtin {
+		merged[i] = MissionSection{Name: section.Name, Missions: append([]MissionInfo(nil), section.Missions...)}
+		index[section.Name] = i
+	}
+	for _, section := range extra {
+		if i, ok := index[section.Name]; ok {
+			merged[i].Missions = append(merged[i].Missions, section.Missions...)
+			continue
+		}
+		index[section.Name] = len(merged)
+		merged = append(merged, section)
+	}
+	return merged
+}
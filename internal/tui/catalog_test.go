@@ -0,0 +1,230 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ZaneH/defuse.party-tui/internal/client"
+	"github.com/ZaneH/defuse.party-tui/internal/client/clienttest"
+	"github.com/ZaneH/defuse.party-tui/internal/custombomb"
+	"github.com/ZaneH/defuse.party-tui/internal/tui/tuitest"
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+func writeCatalog(t *testing.T, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadMissionCatalog(t *testing.T) {
+	extra, err := LoadMissionCatalog(filepath.Join("..", "..", "examples", "missions.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sections := mergeMissionSections(builtinMissionSections, extra)
+
+	if len(sections) != len(builtinMissionSections)+1 {
+		t.Fatalf("got %d sections, want the built-in ones plus one", len(sections))
+	}
+	intro := sections[0].Missions
+	if len(intro) != 2 || intro[1].Mission != pb.Mission_THE_FIRST_BOMB || intro[1].Name != "The First Bomb (Again)" || intro[1].Timer == 0 {
+		t.Errorf("renamed preset = %+v, want The First Bomb's details under a new name", intro[1])
+	}
+	if len(builtinMissionSections[0].Missions) != 1 {
+		t.Error("merging modified the built-in sections")
+	}
+
+	community := sections[len(sections)-1]
+	custom := community.Missions[1].Custom
+	if community.Name != "Community: Warm-ups" || custom == nil {
+		t.Fatalf("last section = %+v, want the community missions", community)
+	}
+	if custom.GetTimerSeconds() != 240 || custom.GetMaxModulesPerFace() != 6 || len(custom.GetModules()) != 3 {
+		t.Errorf("custom bomb = %v", custom)
+	}
+	if custom.GetRows() != 2 || custom.GetColumns() != 3 || custom.GetMinModules() != 5 {
+		t.Errorf("grid = %dx%d with %d modules, want 2x3 with 4 and the clock", custom.GetRows(), custom.GetColumns(), custom.GetMinModules())
+	}
+	if types := custom.GetModules()[0].GetPossibleTypes(); len(types) != 2 || types[0] != pb.Module_PASSWORD {
+		t.Errorf("one_of types = %v, want PASSWORD or MORSE", types)
+	}
+	if regular, needy := community.Missions[1].ModuleCount(); regular != 3 || needy != 1 {
+		t.Errorf("module count = %d + %d needy, want 3 + 1", regular, needy)
+	}
+}
+
+func TestLoadMissionCatalogJSON(t *testing.T) {
+	path := writeCatalog(t, "missions.json", `{
+		"sections": [{
+			"name": "JSON",
+			"missions": [{
+				"id": "json-bomb",
+				"name": "JSON Bomb",
+				"custom": {"timer": "2m", "max_strikes": 1, "faces": 1, "rows": 1, "columns": 3, "modules": [{"type": "MAZE", "count": 2}]}
+			}]
+		}]
+	}`)
+
+	sections, err := LoadMissionCatalog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if custom := sections[0].Missions[0].Custom; custom.GetTimerSeconds() != 120 || custom.GetModules()[0].GetType() != pb.Module_MAZE {
+		t.Errorf("custom bomb = %v", custom)
+	}
+}
+
+func TestLoadMissionCatalogErrors(t *testing.T) {
+	path := writeCatalog(t, "missions.yaml", `
+sections:
+  - name: Broken
+    missions:
+      - id: Bad_ID
+        name: Bad
+        custom:
+          timer: 2s
+          max_strikes: 0
+          faces: 1
+          rows: 1
+          columns: 4
+          modules:
+            - type: WIRES
+              count: 3
+            - type: TOASTER
+              count: 1
+            - type: COMPLICATED_WIRES
+              count: 1
+      - name: Both
+        preset: THE_FIRST_BOMB
+        custom: {timer: 1m}
+      - preset: NOT_A_MISSION
+      - id: dup
+        name: One
+        custom: {timer: 1m, max_strikes: 1, faces: 1, rows: 1, columns: 2, modules: [{type: NEEDY_KNOB, count: 1}]}
+      - id: dup
+        name: Two
+        custom: {timer: 1m, max_strikes: 1, faces: 1, modules: [{type: WIRES, count: 1}]}
+  - name: Empty
+`)
+
+	_, err := LoadMissionCatalog(path)
+	if err == nil {
+		t.Fatal("loaded an invalid catalog")
+	}
+	for _, want := range []string{
+		`sections[0].missions[0] (Bad_ID): id "Bad_ID" may only contain`,
+		"custom.timer: must be between 5 and 3600 seconds",
+		"custom.max_strikes: must be between 1 and 10",
+		`custom.modules[1]: unknown module type "TOASTER"`,
+		`custom.modules[2]: unknown module type "COMPLICATED_WIRES"`,
+		"custom.modules: 6 modules, counting the clock, exceed available slots (4)",
+		"sections[0].missions[4] (dup): custom.rows: must be between 1 and 4",
+		"sections[0].missions[1]: set either preset or custom, not both",
+		`sections[0].missions[2]: unknown preset "NOT_A_MISSION"`,
+		"sections[0].missions[3] (dup): custom.modules must include at least one module that can be solved",
+		`sections[0].missions[4] (dup): id "dup" is already used by sections[0].missions[3] (dup)`,
+		"sections[1]: no missions defined",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error is missing %q:\n%v", want, err)
+		}
+	}
+}
+
+func TestLoadMissionCatalogLargeGrid(t *testing.T) {
+	path := writeCatalog(t, "missions.yaml", `
+sections:
+  - name: Large
+    missions:
+      - id: full-house
+        name: Full House
+        custom:
+          timer: 10m
+          max_strikes: 3
+          faces: 2
+          rows: 4
+          columns: 5
+          modules:
+            - type: WIRES
+              count: 20
+            - type: MAZE
+              count: 9
+`)
+
+	sections, err := LoadMissionCatalog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	custom := sections[0].Missions[0].Custom
+	if custom.GetMaxModulesPerFace() != custombomb.MaxModulesPerFace {
+		t.Errorf("modules per face = %d, want the backend's limit of %d", custom.GetMaxModulesPerFace(), custombomb.MaxModulesPerFace)
+	}
+	if err := custombomb.Validate(custom); err != nil {
+		t.Errorf("the backend would refuse the bomb: %v", err)
+	}
+}
+
+func TestLoadMissionCatalogTooManyModules(t *testing.T) {
+	path := writeCatalog(t, "missions.yaml", `
+sections:
+  - name: Large
+    missions:
+      - id: too-big
+        name: Too Big
+        custom:
+          timer: 10m
+          max_strikes: 3
+          faces: 5
+          rows: 4
+          columns: 5
+          modules:
+            - type: WIRES
+              count: 60
+`)
+
+	_, err := LoadMissionCatalog(path)
+	want := "sections[0].missions[0] (too-big): custom.modules: 61 modules, counting the clock, exceed 60 modules total"
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("err = %v, want %q", err, want)
+	}
+}
+
+func TestLoadMissionCatalogUnknownField(t *testing.T) {
+	path := writeCatalog(t, "missions.yaml", "sections:\n  - name: Typo\n    mision: []\n")
+	if _, err := LoadMissionCatalog(path); err == nil || !strings.Contains(err.Error(), "field mision not found") {
+		t.Errorf("err = %v, want the unknown field reported", err)
+	}
+}
+
+func TestCatalogMission(t *testing.T) {
+	extra, err := LoadMissionCatalog(filepath.Join("..", "..", "examples", "missions.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := clienttest.New(testBomb())
+	m := newModel(Config{Missions: extra}, func() (client.GameClient, error) {
+		return c, nil
+	})
+	d := tuitest.NewDriver(t, m).Send(tea.WindowSizeMsg{Width: 80, Height: 40})
+
+	d.Type("enter", "down", "down", "down", "down", "down", "down", "down", "enter").Snapshot("community missions").
+		Type("down", "enter").Snapshot("briefing").
+		RequireGolden()
+	d.Type("enter")
+
+	configs := c.Configs()
+	if len(configs) != 1 || configs[0].GetCustom().GetTimerSeconds() != 240 {
+		t.Fatalf("CreateGame configs = %v, want the Keep It Turning bomb", configs)
+	}
+	if key := m.gameMission(m.pendingGameConfig); key != "custom:keep-it-turning" {
+		t.Errorf("game recorded as mission %q, want custom:keep-it-turning", key)
+	}
+}
@@ -0,0 +1,373 @@
+package tui
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ZaneH/defuse.party-tui/internal/profile"
+	"github.com/ZaneH/defuse.party-tui/internal/styles"
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+const (
+	dailySeedPrefix      = "daily-"
+	dailyRows            = 2
+	dailyColumns         = 3
+	dailyLeaderboardSize = 10
+)
+
+type DailyOption int
+
+const (
+	DailyPlay DailyOption = iota
+	DailyBack
+)
+
+// dailyDate is the UTC date whose Daily Bomb is offered at t.
+func dailyDate(t time.Time) string {
+	return t.UTC().Format(time.DateOnly)
+}
+
+// dailyMission builds the Daily Bomb for date. It depends only on the date,
+// so every player gets the same bomb; the date also seeds the backend so the
+// modules' puzzles match too.
+func dailyMission(date string) MissionInfo {
+	h := fnv.New64a()
+	h.Write([]byte(dailySeedPrefix + date))
+	seed := h.Sum64()
+	r := rand.New(rand.NewPCG(seed, seed>>32))
+
+	var regular, needy []pb.Module_ModuleType
+	for _, t := range freePlayModuleTypes {
+		if isNeedyModule(t) {
+			needy = append(needy, t)
+		} else {
+			regular = append(regular, t)
+		}
+	}
+
+	timer := 3*time.Minute + time.Duration(r.IntN(7))*30*time.Second
+	strikes := 1 + r.IntN(3)
+	faces := 1 + r.IntN(2)
+	withNeedy := r.IntN(3) == 0
+
+	// The clock takes one slot, and a needy module another.
+	free := faces*dailyRows*dailyColumns - 1
+	if withNeedy {
+		free--
+	}
+	count := min(3+r.IntN(3*faces), free)
+
+	counts := make(map[pb.Module_ModuleType]int)
+	for i := 0; i < count; i++ {
+		counts[regular[r.IntN(len(regular))]]++
+	}
+	if withNeedy {
+		counts[needy[r.IntN(len(needy))]]++
+	}
+
+	mission := MissionInfo{
+		ID:          "daily-" + date,
+		Name:        "Daily Bomb " + date,
+		Description: "Everyone gets this bomb today. Your first attempt is ranked.",
+		Timer:       timer,
+		MaxStrikes:  strikes,
+		Faces:       faces,
+	}
+	custom := &pb.CustomBombConfig{
+		TimerSeconds:      int32(timer / time.Second),
+		MaxStrikes:        int32(strikes),
+		NumFaces:          int32(faces),
+		Rows:              dailyRows,
+		Columns:           dailyColumns,
+		MaxModulesPerFace: dailyRows * dailyColumns,
+	}
+	// Walk the module list rather than the map so the order is stable.
+	for _, t := range freePlayModuleTypes {
+		if n := counts[t]; n > 0 {
+			custom.Modules = append(custom.Modules, &pb.ModuleSpec{Type: t, Count: int32(n)})
+			mission.Modules = append(mission.Modules, MissionModule{Types: []string{t.String()}, Count: n})
+		}
+	}
+	custom.MinModules = int32(count + 1)
+	if withNeedy {
+		custom.MinModules++
+	}
+	mission.Custom = custom
+	return mission
+}
+
+func dailyGameConfig(date string) *pb.GameConfig {
+	config := dailyMission(date).gameConfig()
+	config.Seed = dailySeedPrefix + date
+	return config
+}
+
+func (m *Model) openDaily() tea.Cmd {
+	m.state = StateDaily
+	m.dailyDate = dailyDate(m.now())
+	m.dailySelection = 0
+	m.dailyBoard = nil
+	m.dailyBoardErr = nil
+	return m.loadDailyBoard()
+}
+
+// startDaily starts the Daily Bomb for the day it is now, which is later than
+// the day on screen if the player waited past midnight UTC.
+func (m *Model) startDaily() tea.Cmd {
+	m.dailyDate = dailyDate(m.now())
+	return m.StartGame(dailyGameConfig(m.dailyDate))
+}
+
+func (m *Model) loadDailyBoard() tea.Cmd {
+	if m.profiles == nil {
+		return nil
+	}
+	store, date := m.profiles, m.dailyDate
+	return func() tea.Msg {
+		entries, err := store.DailyLeaderboard(date)
+		return dailyBoardMsg{date: date, entries: entries, err: err}
+	}
+}
+
+func (m *Model) handleDailyBoard(msg dailyBoardMsg) {
+	if msg.date != m.dailyDate {
+		return
+	}
+	m.dailyBoard = msg.entries
+	m.dailyBoardErr = msg.err
+}
+
+// claimDaily uses up the player's ranked attempt once the daily game has
+// actually started. Practice runs and players without a profile stay unranked.
+func (m *Model) claimDaily() tea.Cmd {
+	if m.dailyDate == "" || m.profiles == nil || m.profile == nil {
+		return nil
+	}
+	store, date, id, nickname := m.profiles, m.dailyDate, m.profile.ID, m.profile.Nickname
+	gameID := m.gameID
+	return func() tea.Msg {
+		ranked, err := store.ClaimDaily(date, id, nickname)
+		return dailyClaimedMsg{gameID: gameID, ranked: ranked, err: err}
+	}
+}
+
+func (m *Model) handleDailyClaimed(msg dailyClaimedMsg) tea.Cmd {
+	if msg.gameID != m.gameID {
+		return nil
+	}
+	if msg.err != nil {
+		return m.notify(toastWarning, "Couldn't start a ranked attempt, so this run is practice")
+	}
+	m.dailyRanked = msg.ranked
+	if !msg.ranked {
+		return m.notify(toastInfo, "Practice run: today's ranked attempt is already used")
+	}
+	return m.notify(toastInfo, "Ranked attempt. Good luck!")
+}
+
+// finishDaily posts the result of a ranked daily game to the leaderboard.
+func (m *Model) finishDaily() tea.Cmd {
+	if !m.dailyRanked {
+		return nil
+	}
+	m.dailyRanked = false
+
+	strikes, _ := m.totalStrikes()
+	store, date, id := m.profiles, m.dailyDate, m.profile.ID
+	outcome, duration := m.gameOutcome(), m.endedAt.Sub(m.startedAt)
+	return func() tea.Msg {
+		err := store.FinishDaily(date, id, outcome, duration, int(strikes))
+		return dailyFinishedMsg{err: err}
+	}
+}
+
+func (m *Model) handleDailyFinished(msg dailyFinishedMsg) tea.Cmd {
+	if msg.err != nil {
+		return m.notify(toastWarning, "Couldn't save your Daily Bomb result")
+	}
+	return m.notify(toastInfo, "Daily Bomb result added to the leaderboard")
+}
+
+// dailyEntry finds the player's own attempt on the loaded leaderboard.
+func (m *Model) dailyEntry() (profile.DailyEntry, bool) {
+	if m.profile == nil {
+		return profile.DailyEntry{}, false
+	}
+	for _, entry := range m.dailyBoard {
+		if entry.PlayerID == m.profile.ID {
+			return entry, true
+		}
+	}
+	return profile.DailyEntry{}, false
+}
+
+func (m *Model) dailyOptions() []string {
+	play := "PLAY RANKED"
+	if _, played := m.dailyEntry(); played || m.profiles == nil || m.profile == nil {
+		play = "PRACTICE"
+	}
+	return []string{play, "BACK"}
+}
+
+func (m *Model) handleDailyKeys(key string) (tea.Cmd, bool) {
+	handled := true
+	switch key {
+	case "up", "k":
+		if m.dailySelection > 0 {
+			m.dailySelection--
+		}
+	case "down", "j":
+		if m.dailySelection < len(m.dailyOptions())-1 {
+			m.dailySelection++
+		}
+	case "r", "R":
+		return m.loadDailyBoard(), true
+	case "enter":
+		switch DailyOption(m.dailySelection) {
+		case DailyPlay:
+			return m.startDaily(), true
+		case DailyBack:
+			m.resetToMainMenu()
+		}
+	case "esc":
+		m.resetToMainMenu()
+	default:
+		handled = false
+	}
+	return nil, handled
+}
+
+func formatDailyEntry(rank int, entry profile.DailyEntry) string {
+	name := entry.Nickname
+	if name == "" {
+		name = "Anonymous"
+	}
+	place := "  -"
+	result := ""
+	switch {
+	case entry.Defused():
+		place = fmt.Sprintf("%2d.", rank)
+		result = fmt.Sprintf("%6s  %d✕", formatTimer(entry.Duration), entry.Strikes)
+	case !entry.Finished:
+		result = fmt.Sprintf("%6s", "DNF")
+	default:
+		result = fmt.Sprintf("%6s", strings.ToUpper(entry.Outcome))
+	}
+	return fmt.Sprintf("%s %-*s %s", place, profile.MaxNicknameLength, name, result)
+}
+
+func (m *Model) dailyView() string {
+	header := m.layout().Header().Render(styles.Title.Render("DEFUSE.PARTY"))
+	footer := m.renderFooter()
+	height := m.layout().BodyRows(header, footer)
+
+	// Short terminals show fewer leaderboard entries before scrolling.
+	limit := dailyLeaderboardSize
+	rows := m.dailyRows(limit)
+	if lines, _ := splitRows(rows); height > 0 && len(lines) > height {
+		limit = max(limit-(len(lines)-height), 3)
+		rows = m.dailyRows(limit)
+	}
+	focus := len(rows) - len(m.dailyOptions()) + m.dailySelection
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		header,
+		m.layout().Content().Render(scrollRows(rows, focusOffset(rows, focus, height), height)),
+		footer,
+	)
+}
+
+// dailyRows are the lines of the Daily Bomb screen, showing the top limit
+// players on the leaderboard.
+func (m *Model) dailyRows(limit int) []string {
+	mission := dailyMission(m.dailyDate)
+	regular, needy := mission.ModuleCount()
+
+	summary := fmt.Sprintf("%s on the clock, %d strikes, %d modules", formatTimer(mission.Timer), mission.MaxStrikes, regular)
+	if needy > 0 {
+		summary += fmt.Sprintf(" + %d needy", needy)
+	}
+
+	var status string
+	own, played := m.dailyEntry()
+	switch {
+	case m.profiles == nil || m.profile == nil:
+		status = styles.Help.Render("No profile, so only practice runs are available.")
+	case played:
+		status = styles.Help.Render("You've had today's ranked attempt. Play again for practice.")
+	default:
+		status = styles.Warning.Render("One ranked attempt per day. Make it count.")
+	}
+
+	rows := []string{
+		styles.Title.Render("DAILY BOMB"),
+		styles.Help.Render(m.dailyDate + " (UTC)"),
+		"",
+		summary,
+		status,
+		"",
+		styles.Subtitle.Render("LEADERBOARD"),
+	}
+
+	switch {
+	case m.profiles == nil:
+		rows = append(rows, styles.Help.Render("Leaderboard unavailable"))
+	case m.dailyBoardErr != nil:
+		rows = append(rows, styles.Error.Render("Couldn't load the leaderboard"))
+	case len(m.dailyBoard) == 0:
+		rows = append(rows, styles.Help.Render("No attempts yet. Be the first!"))
+	default:
+		rank, shown := 0, false
+		for i, entry := range m.dailyBoard {
+			if entry.Defused() {
+				rank++
+			}
+			if i >= limit {
+				continue
+			}
+			line := formatDailyEntry(rank, entry)
+			if played && entry.PlayerID == own.PlayerID {
+				line = styles.Active.Render(line)
+				shown = true
+			}
+			rows = append(rows, line)
+		}
+		// Players outside the top still see where they placed.
+		if played && !shown {
+			rows = append(rows, "  ...", styles.Active.Render(formatDailyEntry(m.dailyRank(own), own)))
+		}
+	}
+
+	rows = append(rows, "")
+	for i, option := range m.dailyOptions() {
+		if i == m.dailySelection {
+			rows = append(rows, styles.Active.Render("> "+option))
+		} else {
+			rows = append(rows, "  "+option)
+		}
+	}
+	return wrapRows(rows, m.layout().TextWidth())
+}
+
+// dailyRank is entry's position on the leaderboard, counting only defused
+// bombs.
+func (m *Model) dailyRank(entry profile.DailyEntry) int {
+	rank := 0
+	for _, other := range m.dailyBoard {
+		if other.Defused() {
+			rank++
+		}
+		if other.PlayerID == entry.PlayerID {
+			return rank
+		}
+	}
+	return rank
+}
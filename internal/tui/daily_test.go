@@ -0,0 +1,164 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ZaneH/defuse.party-tui/internal/client"
+	"github.com/ZaneH/defuse.party-tui/internal/client/clienttest"
+	"github.com/ZaneH/defuse.party-tui/internal/custombomb"
+	"github.com/ZaneH/defuse.party-tui/internal/profile"
+	"github.com/ZaneH/defuse.party-tui/internal/tui/tuitest"
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+func TestDailyMission(t *testing.T) {
+	if !proto.Equal(dailyGameConfig("2026-10-16"), dailyGameConfig("2026-10-16")) {
+		t.Error("the same date gave different bombs")
+	}
+	if got := dailyGameConfig("2026-10-16").GetSeed(); got != "daily-2026-10-16" {
+		t.Errorf("seed = %q, want daily-2026-10-16", got)
+	}
+
+	distinct := make(map[string]bool)
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 365; i++ {
+		date := dailyDate(day.AddDate(0, 0, i))
+		mission := dailyMission(date)
+		custom := mission.Custom
+
+		regular, needy := mission.ModuleCount()
+		if regular < 3 || int32(regular+needy+1) != custom.GetMinModules() {
+			t.Errorf("%s: %d + %d needy modules, min_modules %d", date, regular, needy, custom.GetMinModules())
+		}
+		// Every module and the clock must fit, or the backend drops some.
+		if err := custombomb.Validate(custom); err != nil {
+			t.Errorf("%s: the backend would refuse the bomb: %v", date, err)
+		}
+		if custom.GetTimerSeconds() < 180 || custom.GetMaxStrikes() < 1 {
+			t.Errorf("%s: bomb = %v", date, custom)
+		}
+		distinct[custom.String()] = true
+	}
+	if len(distinct) < 300 {
+		t.Errorf("only %d distinct bombs in a year", len(distinct))
+	}
+}
+
+func TestDailyDateIsUTC(t *testing.T) {
+	late := time.Date(2026, 10, 16, 22, 0, 0, 0, time.FixedZone("UTC-5", -5*60*60))
+	if got := dailyDate(late); got != "2026-10-17" {
+		t.Errorf("dailyDate = %s, want the UTC date 2026-10-17", got)
+	}
+}
+
+func TestDailyBomb(t *testing.T) {
+	store, err := profile.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	const date = "2026-10-16"
+	if _, err := store.ClaimDaily(date, "user:bob", "Bob"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.FinishDaily(date, "user:bob", "defused", 2*time.Minute, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.SetNickname("user:ada", "Ada"); err != nil {
+		t.Fatal(err)
+	}
+	p, err := store.Load("user:ada")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := clienttest.New(testBomb())
+	c.QueueResult(
+		&pb.PlayerInputResult{ModuleId: "wires", Solved: true, BombStatus: &pb.BombStatus{StrikeCount: 0, MaxStrikes: 3}},
+		&pb.PlayerInputResult{ModuleId: "wires", Solved: true, BombStatus: &pb.BombStatus{StrikeCount: 0, MaxStrikes: 3}},
+	)
+	m := newModel(Config{}, func() (client.GameClient, error) {
+		return c, nil
+	})
+	m.now = func() time.Time { return testNow }
+	m.attachProfile(store, p)
+	d := tuitest.NewDriver(t, m).Send(tea.WindowSizeMsg{Width: 80, Height: 40})
+
+	d.Type("down", "down", "enter").Snapshot("before ranked attempt")
+	d.Type("enter", "enter", "2", "1")
+	if m.state != StateGameOver {
+		t.Fatalf("state = %v, want game over", m.state)
+	}
+
+	entries, err := store.DailyLeaderboard(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].PlayerID != "user:ada" || !entries[0].Defused() {
+		t.Fatalf("leaderboard = %+v, want Ada's defusal on top", entries)
+	}
+
+	d.Type("esc", "down", "down", "enter").Snapshot("after ranked attempt").RequireGolden()
+
+	// A second go is practice and leaves the ranked result alone.
+	c.Bombs = []*pb.Bomb{testBomb()}
+	d.Type("enter", "enter", "2", "1")
+	again, err := store.DailyLeaderboard(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again) != 2 || again[0] != entries[0] {
+		t.Errorf("leaderboard after practice = %+v, want it unchanged", again)
+	}
+
+	configs := c.Configs()
+	if len(configs) != 2 || !proto.Equal(configs[0], dailyGameConfig(date)) || !proto.Equal(configs[1], configs[0]) {
+		t.Errorf("CreateGame configs = %v, want today's Daily Bomb twice", configs)
+	}
+	if p, err := store.Load("user:ada"); err != nil || len(p.History) != 2 || p.History[0].Mode != "daily" {
+		t.Errorf("history = %+v, %v; want two daily games", p.History, err)
+	}
+}
+
+func TestDailyBombAfterMidnight(t *testing.T) {
+	store, err := profile.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	if _, err := store.SetNickname("user:ada", "Ada"); err != nil {
+		t.Fatal(err)
+	}
+	p, err := store.Load("user:ada")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := clienttest.New(testBomb())
+	m := newTestModel(c)
+	m.attachProfile(store, p)
+	d := tuitest.NewDriver(t, m).Send(tea.WindowSizeMsg{Width: 80, Height: 40})
+
+	// The screen opens on the 16th and the game starts on the 17th.
+	d.Type("down", "down", "enter")
+	if m.dailyDate != "2026-10-16" {
+		t.Fatalf("daily screen shows %s, want 2026-10-16", m.dailyDate)
+	}
+	m.now = func() time.Time { return testNow.Add(time.Hour) }
+	d.Type("enter")
+
+	configs := c.Configs()
+	if len(configs) != 1 || configs[0].GetSeed() != "daily-2026-10-17" {
+		t.Fatalf("CreateGame configs = %v, want the bomb for 2026-10-17", configs)
+	}
+	if ranked, err := store.ClaimDaily("2026-10-17", "user:ada", "Ada"); err != nil || ranked {
+		t.Errorf("ClaimDaily on the 17th = %v, %v; want the attempt already used", ranked, err)
+	}
+	if ranked, err := store.ClaimDaily("2026-10-16", "user:ada", "Ada"); err != nil || !ranked {
+		t.Errorf("ClaimDaily on the 16th = %v, %v; want the attempt still free", ranked, err)
+	}
+}
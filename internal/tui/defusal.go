@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"time"
+
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+type moduleSolve struct {
+	bombIndex  int
+	moduleID   string
+	moduleType pb.Module_ModuleType
+	elapsed    time.Duration
+}
+
+func isNeedyModule(t pb.Module_ModuleType) bool {
+	return t == pb.Module_NEEDY_VENT_GAS || t == pb.Module_NEEDY_KNOB
+}
+
+// requiresSolve reports whether a module counts towards defusing its bomb.
+// The clock and needy modules can never be solved.
+func requiresSolve(t pb.Module_ModuleType) bool {
+	return t != pb.Module_CLOCK && !isNeedyModule(t)
+}
+
+func (m *Model) findModule(moduleID string) (int, *pb.Module) {
+	for i, bomb := range m.bombs {
+		for _, mod := range bomb.GetModules() {
+			if mod.GetId() == moduleID {
+				return i, mod
+			}
+		}
+	}
+	return -1, nil
+}
+
+// markModuleSolved flags the module as solved in the cached bombs and
+// records its place in the solve order. Repeated calls are ignored.
+func (m *Model) markModuleSolved(moduleID string) {
+	bombIndex, mod := m.findModule(moduleID)
+	if mod == nil || mod.GetSolved() {
+		return
+	}
+
+	mod.Solved = true
+	m.stats.solved(moduleID, m.now())
+	if cached, exists := m.moduleCache[moduleID]; exists {
+		cached.UpdateState(&pb.Module{Id: moduleID, Solved: true})
+	}
+
+	if requiresSolve(mod.GetType()) {
+		m.solveOrder = append(m.solveOrder, moduleSolve{
+			bombIndex:  bombIndex,
+			moduleID:   moduleID,
+			moduleType: mod.GetType(),
+			elapsed:    m.now().Sub(m.startedAt),
+		})
+	}
+}
+
+func (m *Model) allBombsDefused() bool {
+	if len(m.bombs) == 0 {
+		return false
+	}
+
+	solvable := 0
+	for _, bomb := range m.bombs {
+		for _, mod := range bomb.GetModules() {
+			if !requiresSolve(mod.GetType()) {
+				continue
+			}
+			solvable++
+			if !mod.GetSolved() {
+				return false
+			}
+		}
+	}
+	return solvable > 0
+}
+
+func (m *Model) totalStrikes() (strikes, maxStrikes int32) {
+	for _, bomb := range m.bombs {
+		strikes += bomb.GetStrikeCount()
+		maxStrikes += bomb.GetMaxStrikes()
+	}
+	return strikes, maxStrikes
+}
+
+func (m *Model) timeRemaining() time.Duration {
+	end := m.endedAt
+	if end.IsZero() {
+		end = m.now()
+	}
+	remaining := m.duration - end.Sub(m.startedAt)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
@@ -1,6 +1,10 @@
 package tui
 
 import (
+	"fmt"
+	"slices"
+
+	"github.com/ZaneH/defuse.party-tui/internal/custombomb"
 	"github.com/ZaneH/defuse.party-tui/internal/styles"
 	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
 	tea "github.com/charmbracelet/bubbletea"
@@ -15,6 +19,8 @@ var freePlayPresets = []string{
 	"ADVANCED...",
 }
 
+// freePlayModuleTypes is every module type that both the backend and the
+// client can play.
 var freePlayModuleTypes = []pb.Module_ModuleType{
 	pb.Module_WIRES,
 	pb.Module_PASSWORD,
@@ -29,6 +35,10 @@ var freePlayModuleTypes = []pb.Module_ModuleType{
 	pb.Module_NEEDY_KNOB,
 }
 
+func playableModule(t pb.Module_ModuleType) bool {
+	return slices.Contains(freePlayModuleTypes, t)
+}
+
 var freePlayModuleNames = []string{
 	"Wires",
 	"Password",
@@ -64,8 +74,8 @@ func (m *Model) freePlayMenuView() string {
 
 	return lipgloss.JoinVertical(
 		lipgloss.Top,
-		styles.HeaderBox.Render(styles.Title.Render("DEFUSE.PARTY")),
-		styles.ContentBox.Render(content),
+		m.layout().Header().Render(styles.Title.Render("DEFUSE.PARTY")),
+		m.layout().Content().Render(content),
 		m.renderFooter(),
 	)
 }
@@ -84,37 +94,29 @@ func (m *Model) handleFreePlayMenuKeys(key string) (tea.Cmd, bool) {
 	case "enter":
 		switch m.freePlaySelection {
 		case 0:
-			m.pendingGameConfig = &pb.GameConfig{
+			return m.StartGame(&pb.GameConfig{
 				ConfigType: &pb.GameConfig_Level{
 					Level: &pb.LevelConfig{Level: 1},
 				},
-			}
-			m.state = StateLoading
-			return m.StartGame(m.pendingGameConfig), true
+			}), true
 		case 1:
-			m.pendingGameConfig = &pb.GameConfig{
+			return m.StartGame(&pb.GameConfig{
 				ConfigType: &pb.GameConfig_Level{
 					Level: &pb.LevelConfig{Level: 3},
 				},
-			}
-			m.state = StateLoading
-			return m.StartGame(m.pendingGameConfig), true
+			}), true
 		case 2:
-			m.pendingGameConfig = &pb.GameConfig{
+			return m.StartGame(&pb.GameConfig{
 				ConfigType: &pb.GameConfig_Level{
 					Level: &pb.LevelConfig{Level: 5},
 				},
-			}
-			m.state = StateLoading
-			return m.StartGame(m.pendingGameConfig), true
+			}), true
 		case 3:
-			m.pendingGameConfig = &pb.GameConfig{
+			return m.StartGame(&pb.GameConfig{
 				ConfigType: &pb.GameConfig_Level{
 					Level: &pb.LevelConfig{Level: 7},
 				},
-			}
-			m.state = StateLoading
-			return m.StartGame(m.pendingGameConfig), true
+			}), true
 		case 4:
 			m.state = StateFreePlayAdvanced
 			m.freePlayConfig = DefaultFreePlayConfig()
@@ -160,56 +162,85 @@ func (m *Model) freePlayAdvancedView() string {
 	}
 	rows = append(rows, facesRow)
 
-	modulesRow := "  Modules/Face:    "
+	grid := fmt.Sprintf("%dx%d", m.freePlayConfig.Rows, m.freePlayConfig.Columns)
+	gridRow := "  Face Grid:       "
 	if m.freePlayCursor == 3 {
-		modulesRow += styles.Active.Render("◀ " + formatTwoDigits(m.freePlayConfig.ModulesPerFace) + " ▶")
+		gridRow += styles.Active.Render("◀ " + grid + " ▶")
 	} else {
-		modulesRow += "◀ " + formatTwoDigits(m.freePlayConfig.ModulesPerFace) + " ▶"
+		gridRow += "◀ " + grid + " ▶"
 	}
-	rows = append(rows, modulesRow)
+	rows = append(rows, gridRow)
 
 	rows = append(rows, "")
 	rows = append(rows, "  Modules:")
 	rows = append(rows, "")
 
+	// Two columns of modules fit unless the terminal is narrow.
+	columns := 2
+	if m.layout().TextWidth() < 2*32 {
+		columns = 1
+	}
 	moduleCols := [][]string{}
-	for i := 0; i < len(freePlayModuleTypes); i += 2 {
+	for i := 0; i < len(freePlayModuleTypes); i += columns {
 		var row []string
-		for j := 0; j < 2 && i+j < len(freePlayModuleTypes); j++ {
+		for j := 0; j < columns && i+j < len(freePlayModuleTypes); j++ {
 			idx := i + j
-			moduleType := freePlayModuleTypes[idx]
-			name := freePlayModuleNames[idx]
-			enabled := m.freePlayConfig.EnabledModules[moduleType]
-			checkbox := "[ ]"
-			if enabled {
-				checkbox = "[x]"
-			}
-			if m.freePlayInModules && m.freePlayCursor-4 == idx {
-				row = append(row, styles.Active.Render("  "+checkbox+" "+name))
-			} else {
-				row = append(row, "  "+checkbox+" "+name)
+			count := m.freePlayConfig.ModuleCounts[freePlayModuleTypes[idx]]
+			entry := fmt.Sprintf("  %-15s ◀ %2d ▶", freePlayModuleNames[idx], count)
+			switch {
+			case m.freePlayInModules && m.freePlayCursor-4 == idx:
+				entry = styles.Active.Render(entry)
+			case count == 0:
+				entry = styles.Help.Render(entry)
 			}
+			row = append(row, lipgloss.NewStyle().Width(32).Render(entry))
 		}
 		moduleCols = append(moduleCols, row)
 	}
 
+	// The row holding the cursor is kept on screen when they don't all fit.
+	focus := m.freePlayCursor + 1
+	if m.freePlayInModules {
+		focus = len(rows) + (m.freePlayCursor-4)/columns
+	}
 	for _, row := range moduleCols {
 		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Left, row...))
 	}
 
 	rows = append(rows, "")
-	rows = append(rows, "                     [ START GAME ]")
+	err := m.freePlayConfig.Validate()
+	capacity := fmt.Sprintf("  Slots used:      %d / %d", m.freePlayConfig.TotalModules(), m.freePlayConfig.Capacity())
+	if err != nil {
+		rows = append(rows, styles.Error.Render(capacity))
+		rows = append(rows, styles.Error.Render("  ✖ "+err.Error()))
+	} else {
+		rows = append(rows, styles.Success.Render(capacity))
+		rows = append(rows, "")
+	}
 
-	content := lipgloss.JoinVertical(
-		lipgloss.Left,
-		rows...,
-	)
+	rows = append(rows, "")
+	start := "                     [ START GAME ]"
+	switch {
+	case err != nil:
+		start = styles.Help.Render(start)
+	case m.freePlayInModules && m.freePlayCursor == 4+len(freePlayModuleTypes):
+		start = styles.Active.Render(start)
+	}
+	if m.freePlayInModules && m.freePlayCursor == 4+len(freePlayModuleTypes) {
+		focus = len(rows)
+	}
+	rows = append(rows, start)
+	rows = wrapRows(rows, m.layout().TextWidth())
+
+	header := m.layout().Header().Render(styles.Title.Render("DEFUSE.PARTY"))
+	footer := m.renderFooter()
+	height := m.layout().BodyRows(header, footer)
 
 	return lipgloss.JoinVertical(
 		lipgloss.Top,
-		styles.HeaderBox.Render(styles.Title.Render("DEFUSE.PARTY")),
-		styles.ContentBox.Render(content),
-		m.renderFooter(),
+		header,
+		m.layout().Content().Render(scrollRows(rows, focusOffset(rows, focus, height), height)),
+		footer,
 	)
 }
 
@@ -238,7 +269,9 @@ func (m *Model) handleFreePlayAdvancedKeys(key string) (tea.Cmd, bool) {
 			m.freePlayCursor++
 		}
 	case "left", "h":
-		if !m.freePlayInModules {
+		if m.freePlayInModules {
+			m.adjustModuleCount(-1)
+		} else {
 			switch m.freePlayCursor {
 			case 0:
 				if m.freePlayConfig.TimerSeconds > 30 {
@@ -253,20 +286,20 @@ func (m *Model) handleFreePlayAdvancedKeys(key string) (tea.Cmd, bool) {
 					m.freePlayConfig.NumFaces--
 				}
 			case 3:
-				if m.freePlayConfig.ModulesPerFace > 1 {
-					m.freePlayConfig.ModulesPerFace--
-				}
+				m.freePlayConfig.stepFaceGrid(-1)
 			}
 		}
 	case "right", "l":
-		if !m.freePlayInModules {
+		if m.freePlayInModules {
+			m.adjustModuleCount(1)
+		} else {
 			switch m.freePlayCursor {
 			case 0:
-				if m.freePlayConfig.TimerSeconds < 3600 {
+				if m.freePlayConfig.TimerSeconds < custombomb.MaxTimerSeconds {
 					m.freePlayConfig.TimerSeconds += 30
 				}
 			case 1:
-				if m.freePlayConfig.MaxStrikes < 10 {
+				if m.freePlayConfig.MaxStrikes < custombomb.MaxStrikes {
 					m.freePlayConfig.MaxStrikes++
 				}
 			case 2:
@@ -274,22 +307,20 @@ func (m *Model) handleFreePlayAdvancedKeys(key string) (tea.Cmd, bool) {
 					m.freePlayConfig.NumFaces++
 				}
 			case 3:
-				if m.freePlayConfig.ModulesPerFace < 12 {
-					m.freePlayConfig.ModulesPerFace++
-				}
+				m.freePlayConfig.stepFaceGrid(1)
 			}
 		}
 	case " ":
-		if m.freePlayInModules {
-			idx := m.freePlayCursor - 4
-			if idx >= 0 && idx < len(freePlayModuleTypes) {
-				moduleType := freePlayModuleTypes[idx]
-				m.freePlayConfig.EnabledModules[moduleType] = !m.freePlayConfig.EnabledModules[moduleType]
+		if moduleType, ok := m.selectedFreePlayModule(); ok {
+			if m.freePlayConfig.ModuleCounts[moduleType] > 0 {
+				m.freePlayConfig.ModuleCounts[moduleType] = 0
+			} else {
+				m.freePlayConfig.ModuleCounts[moduleType] = 1
 			}
 		}
 	case "enter":
 		if m.freePlayInModules && m.freePlayCursor == 4+len(freePlayModuleTypes) {
-			m.buildAndStartCustomGame()
+			return m.buildAndStartCustomGame(), true
 		}
 	case "esc":
 		m.state = StateFreePlayMenu
@@ -300,27 +331,33 @@ func (m *Model) handleFreePlayAdvancedKeys(key string) (tea.Cmd, bool) {
 	return nil, handled
 }
 
-func (m *Model) buildAndStartCustomGame() {
-	var modules []*pb.ModuleSpec
-	for i, moduleType := range freePlayModuleTypes {
-		if m.freePlayConfig.EnabledModules[moduleType] {
-			modules = append(modules, &pb.ModuleSpec{
-				Type:  freePlayModuleTypes[i],
-				Count: int32(m.freePlayConfig.ModulesPerFace),
-			})
-		}
+func (m *Model) selectedFreePlayModule() (pb.Module_ModuleType, bool) {
+	idx := m.freePlayCursor - 4
+	if !m.freePlayInModules || idx < 0 || idx >= len(freePlayModuleTypes) {
+		return 0, false
 	}
+	return freePlayModuleTypes[idx], true
+}
 
-	m.pendingGameConfig = &pb.GameConfig{
-		ConfigType: &pb.GameConfig_Custom{
-			Custom: &pb.CustomBombConfig{
-				TimerSeconds:      int32(m.freePlayConfig.TimerSeconds),
-				MaxStrikes:        int32(m.freePlayConfig.MaxStrikes),
-				NumFaces:          int32(m.freePlayConfig.NumFaces),
-				Modules:           modules,
-				MaxModulesPerFace: int32(m.freePlayConfig.ModulesPerFace),
-			},
-		},
+func (m *Model) adjustModuleCount(delta int) {
+	moduleType, ok := m.selectedFreePlayModule()
+	if !ok {
+		return
+	}
+	count := m.freePlayConfig.ModuleCounts[moduleType] + delta
+	if count >= 0 && count <= maxModuleCount {
+		m.freePlayConfig.ModuleCounts[moduleType] = count
 	}
-	m.state = StateLoading
+}
+
+// buildAndStartCustomGame launches the configured bomb. Invalid configs are
+// already flagged on screen, so they are simply not started.
+func (m *Model) buildAndStartCustomGame() tea.Cmd {
+	if m.freePlayConfig.Validate() != nil {
+		return nil
+	}
+
+	return m.StartGame(&pb.GameConfig{
+		ConfigType: &pb.GameConfig_Custom{Custom: m.freePlayConfig.customBomb()},
+	})
 }
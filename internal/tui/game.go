@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,7 +11,7 @@ import (
 )
 
 func (m *Model) bombSelectionView() string {
-	header := m.renderHeader(time.Now())
+	header := m.renderHeader(m.now())
 	footer := m.renderFooter()
 
 	var bombList []string
@@ -38,13 +39,13 @@ func (m *Model) bombSelectionView() string {
 	return lipgloss.JoinVertical(
 		lipgloss.Top,
 		header,
-		styles.ContentBox.Render(content),
+		m.layout().Content().Render(content),
 		footer,
 	)
 }
 
 func (m *Model) bombView() string {
-	header := m.renderHeader(time.Now())
+	header := m.renderHeader(m.now())
 	footer := m.renderFooter()
 
 	faceModules := m.getCurrentFaceModules()
@@ -63,13 +64,19 @@ func (m *Model) bombView() string {
 		if timer != "" {
 			nameLen := len(moduleLine)
 			timerLen := len(timer)
-			padding := 40 - nameLen - timerLen
+			padding := min(40, m.layout().TextWidth()) - nameLen - timerLen
 			if padding < 1 {
 				padding = 1
 			}
 			moduleLine = fmt.Sprintf("%s%*s%s", moduleLine, padding, "", timer)
 		}
 
+		if isNeedyModule(mod.GetType()) {
+			if remaining, active := m.needyRemaining(mod, m.now()); active && remaining < needyUrgentThreshold {
+				status = styles.Error.Bold(true).Render("! NEEDS ATTENTION")
+			}
+		}
+
 		if i == m.selectedModule {
 			modules = append(modules, styles.Active.Render(moduleLine))
 			modules = append(modules, styles.Active.Render(fmt.Sprintf("  > SELECTED    %s", status)))
@@ -97,23 +104,38 @@ func (m *Model) bombView() string {
 	return lipgloss.JoinVertical(
 		lipgloss.Top,
 		header,
-		styles.ContentBox.Render(content),
+		m.layout().Content().Render(content),
 		footer,
 	)
 }
 
+type GameOverOption int
+
+const (
+	GameOverReturnToMenu GameOverOption = iota
+	GameOverPlayAgain
+	GameOverQuit
+)
+
+var gameOverOptions = []string{
+	"RETURN TO MENU",
+	"PLAY AGAIN",
+	"QUIT",
+}
+
 func (m *Model) gameOverView() string {
+	if m.showReport {
+		return m.reportJSONView()
+	}
+
 	var title string
-	if m.err != nil {
-		if m.err.Error() == "BOOM! The bomb exploded." {
-			title = "GAME OVER"
-		} else if m.err.Error() == "time's up!" {
-			title = "TIME'S UP!"
-		} else {
-			title = "GAME OVER"
-		}
-	} else {
+	switch {
+	case m.err == nil:
 		title = "CONGRATULATIONS!"
+	case errors.Is(m.err, errTimeUp):
+		title = "TIME'S UP!"
+	default:
+		title = "GAME OVER"
 	}
 
 	errMsg := ""
@@ -121,9 +143,8 @@ func (m *Model) gameOverView() string {
 		errMsg = m.err.Error()
 	}
 
-	options := []string{"RETURN TO MENU", "QUIT"}
 	var optionLines []string
-	for i, opt := range options {
+	for i, opt := range gameOverOptions {
 		if i == m.gameOverSelection {
 			optionLines = append(optionLines, styles.Active.Render("> "+opt))
 		} else {
@@ -131,11 +152,19 @@ func (m *Model) gameOverView() string {
 		}
 	}
 
+	summary := styles.Subtitle.Render(errMsg)
+	if m.err == nil {
+		summary = m.victorySummaryView()
+	}
+	if m.stats != nil {
+		summary = lipgloss.JoinVertical(lipgloss.Center, summary, "", m.reportTableView())
+	}
+
 	content := lipgloss.JoinVertical(
 		lipgloss.Center,
 		styles.Title.Render(title),
 		"",
-		styles.Subtitle.Render(errMsg),
+		summary,
 		"",
 		"",
 		lipgloss.JoinVertical(lipgloss.Center, optionLines...),
@@ -143,16 +172,40 @@ func (m *Model) gameOverView() string {
 
 	return lipgloss.JoinVertical(
 		lipgloss.Top,
-		styles.HeaderBox.Render(styles.Title.Render("DEFUSE.PARTY")),
-		styles.ContentBox.Render(content),
+		m.layout().Header().Render(styles.Title.Render("DEFUSE.PARTY")),
+		m.layout().Content().Render(content),
 		m.renderFooter(),
 	)
 }
 
+func (m *Model) victorySummaryView() string {
+	strikes, maxStrikes := m.totalStrikes()
+
+	lines := []string{
+		styles.Success.Render("All bombs defused!"),
+		"",
+		fmt.Sprintf("Time remaining: %s", formatTimer(m.timeRemaining())),
+		fmt.Sprintf("Strikes used:   %d / %d", strikes, maxStrikes),
+	}
+
+	if len(m.solveOrder) > 0 {
+		lines = append(lines, "", styles.Subtitle.Render("Solve order:"))
+		for i, solve := range m.solveOrder {
+			line := fmt.Sprintf("%2d. %-16s %s", i+1, m.moduleTypeName(solve.moduleType), formatTimer(solve.elapsed))
+			if len(m.bombs) > 1 {
+				line += fmt.Sprintf("  (bomb %d)", solve.bombIndex+1)
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
 func (m *Model) renderHeader(now time.Time) string {
 	bomb := m.getCurrentBomb()
 	if bomb == nil {
-		return styles.HeaderBox.Render(
+		return m.layout().Header().Render(
 			lipgloss.JoinHorizontal(
 				lipgloss.Left,
 				styles.Title.Render("DEFUSE.PARTY - DEFUSER TERMINAL"),
@@ -192,67 +245,159 @@ func (m *Model) renderHeader(now time.Time) string {
 
 	serial := bomb.GetSerialNumber()
 	batteries := bomb.GetBatteries()
-	ports := bomb.GetPorts()
-	portStr := ""
-	if len(ports) > 0 {
-		var portNames []string
-		for _, p := range ports {
-			switch p {
-			case pb.Port_DVID:
-				portNames = append(portNames, "DVI")
-			case pb.Port_RCA:
-				portNames = append(portNames, "RCA")
-			case pb.Port_PS2:
-				portNames = append(portNames, "PS2")
-			case pb.Port_RJ45:
-				portNames = append(portNames, "RJ45")
-			case pb.Port_SERIAL:
-				portNames = append(portNames, "SER")
-			}
-		}
-		portStr = fmt.Sprintf("Ports: %s", joinStrings(portNames, ", "))
-	}
-
-	batteryStr := ""
-	if batteries > 0 {
-		batteryStr = fmt.Sprintf("Batteries: %d", batteries)
-	}
-
-	headerContent := lipgloss.JoinHorizontal(
-		lipgloss.Left,
-		styles.Title.Render("DEFUSE.PARTY"),
-		"  ",
-		timerStyle.Render(fmt.Sprintf("Time: %s", timerStr)),
-		"  ",
-		styles.Normal.Render(fmt.Sprintf("Serial: %s", serial)),
-	)
-
-	if batteryStr != "" || portStr != "" {
+	ports := portNames(bomb.GetPorts())
+
+	var headerContent string
+	if layout := m.layout(); layout.Compact() {
+		// Narrow terminals drop the title and squeeze the edgework onto
+		// whatever room the strikes leave.
+		batteryStr := fmt.Sprintf("Bat: %d", batteries)
+		room := layout.BoxWidth() - 4 - lipgloss.Width(strikes) - len(batteryStr) - 4
 		headerContent = lipgloss.JoinVertical(
 			lipgloss.Left,
-			headerContent,
+			lipgloss.JoinHorizontal(
+				lipgloss.Left,
+				timerStyle.Render(fmt.Sprintf("Time: %s", timerStr)),
+				"  ",
+				styles.Normal.Render(fmt.Sprintf("Serial: %s", serial)),
+			),
 			lipgloss.JoinHorizontal(
 				lipgloss.Left,
 				styles.Normal.Render(strikes),
 				"  ",
 				styles.Help.Render(batteryStr),
 				"  ",
-				styles.Help.Render(portStr),
+				styles.Help.Render(abbreviatePorts(ports, room)),
 			),
 		)
 	} else {
+		portStr := ""
+		if len(ports) > 0 {
+			portStr = fmt.Sprintf("Ports: %s", joinStrings(ports, ", "))
+		}
+
+		batteryStr := ""
+		if batteries > 0 {
+			batteryStr = fmt.Sprintf("Batteries: %d", batteries)
+		}
+
+		headerContent = lipgloss.JoinHorizontal(
+			lipgloss.Left,
+			styles.Title.Render("DEFUSE.PARTY"),
+			"  ",
+			timerStyle.Render(fmt.Sprintf("Time: %s", timerStr)),
+			"  ",
+			styles.Normal.Render(fmt.Sprintf("Serial: %s", serial)),
+		)
+
+		if batteryStr != "" || portStr != "" {
+			headerContent = lipgloss.JoinVertical(
+				lipgloss.Left,
+				headerContent,
+				lipgloss.JoinHorizontal(
+					lipgloss.Left,
+					styles.Normal.Render(strikes),
+					"  ",
+					styles.Help.Render(batteryStr),
+					"  ",
+					styles.Help.Render(portStr),
+				),
+			)
+		} else {
+			headerContent = lipgloss.JoinVertical(
+				lipgloss.Left,
+				headerContent,
+				styles.Normal.Render(strikes),
+			)
+		}
+	}
+
+	if alerts := m.renderNeedyAlerts(now); alerts != "" {
+		headerContent = lipgloss.JoinVertical(
+			lipgloss.Left,
+			headerContent,
+			alerts,
+		)
+	}
+
+	if m.syncErr != nil {
 		headerContent = lipgloss.JoinVertical(
 			lipgloss.Left,
 			headerContent,
-			styles.Normal.Render(strikes),
+			styles.Warning.Render(fmt.Sprintf("Sync lost (last synced %s ago), retrying...", formatTimer(now.Sub(m.lastSyncedAt)))),
 		)
 	}
 
-	return styles.HeaderBox.Render(headerContent)
+	if status := m.renderBackendStatus(now); status != "" {
+		headerContent = lipgloss.JoinVertical(
+			lipgloss.Left,
+			headerContent,
+			status,
+		)
+	}
+
+	return m.layout().Header().Render(headerContent)
+}
+
+func portNames(ports []pb.Port) []string {
+	var names []string
+	for _, p := range ports {
+		switch p {
+		case pb.Port_DVID:
+			names = append(names, "DVI")
+		case pb.Port_RCA:
+			names = append(names, "RCA")
+		case pb.Port_PS2:
+			names = append(names, "PS2")
+		case pb.Port_RJ45:
+			names = append(names, "RJ45")
+		case pb.Port_SERIAL:
+			names = append(names, "SER")
+		}
+	}
+	return names
+}
+
+// abbreviatePorts lists ports in at most width columns for the compact
+// header. Repeats are counted rather than listed, and ports that still don't
+// fit are summarised as "+N".
+func abbreviatePorts(names []string, width int) string {
+	counts := make(map[string]int)
+	var unique []string
+	for _, name := range names {
+		if counts[name] == 0 {
+			unique = append(unique, name)
+		}
+		counts[name]++
+	}
+
+	var entries []string
+	for _, name := range unique {
+		if counts[name] > 1 {
+			name = fmt.Sprintf("%s×%d", name, counts[name])
+		}
+		entries = append(entries, name)
+	}
+
+	list := "P:"
+	for i, entry := range entries {
+		more := ""
+		if rest := len(entries) - i - 1; rest > 0 {
+			more = fmt.Sprintf(" +%d", rest)
+		}
+		if lipgloss.Width(list+" "+entry+more) > width {
+			return fmt.Sprintf("%s +%d", list, len(entries)-i)
+		}
+		list += " " + entry
+	}
+	if len(entries) == 0 {
+		list += " -"
+	}
+	return list
 }
 
 func (m *Model) getModuleTimer(mod *pb.Module) string {
-	now := time.Now()
+	now := m.now()
 
 	if mod.GetType() == pb.Module_CLOCK {
 		elapsed := now.Sub(m.startedAt)
@@ -265,35 +410,9 @@ func (m *Model) getModuleTimer(mod *pb.Module) string {
 		return fmt.Sprintf("[%d:%02d]", mins, secs)
 	}
 
-	if mod.GetType() == pb.Module_NEEDY_VENT_GAS {
-		if state := mod.GetNeedyVentGasState(); state != nil {
-			if state.GetCountdownStartedAt() == 0 {
-				return ""
-			}
-			startedAt := time.Unix(state.GetCountdownStartedAt(), 0)
-			elapsed := now.Sub(startedAt)
-			remaining := time.Duration(state.GetCountdownDuration())*time.Second - elapsed
-			if remaining < 0 {
-				remaining = 0
-			}
-			secs := int(remaining.Seconds())
-			return fmt.Sprintf("[0:%02d]", secs)
-		}
-	}
-
-	if mod.GetType() == pb.Module_NEEDY_KNOB {
-		if state := mod.GetNeedyKnobState(); state != nil {
-			if state.GetCountdownStartedAt() == 0 {
-				return ""
-			}
-			startedAt := time.Unix(state.GetCountdownStartedAt(), 0)
-			elapsed := now.Sub(startedAt)
-			remaining := time.Duration(state.GetCountdownDuration())*time.Second - elapsed
-			if remaining < 0 {
-				remaining = 0
-			}
-			secs := int(remaining.Seconds())
-			return fmt.Sprintf("[0:%02d]", secs)
+	if isNeedyModule(mod.GetType()) {
+		if remaining, active := m.needyRemaining(mod, now); active {
+			return fmt.Sprintf("[0:%02d]", int(remaining.Seconds()))
 		}
 	}
 
@@ -1,44 +1,131 @@
 package tui
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ZaneH/defuse.party-tui/internal/custombomb"
 	"github.com/ZaneH/defuse.party-tui/internal/styles"
 	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
 )
 
+// maxModuleCount caps how many copies of one module type a custom bomb may
+// have.
+const maxModuleCount = 12
+
+// faceGrids are the module layouts a bomb face can have, smallest first,
+// within the backend's limit of 4 rows by 5 columns.
+var faceGrids = [][2]int{{1, 1}, {1, 2}, {1, 3}, {2, 2}, {2, 3}, {2, 4}, {3, 3}, {3, 4}, {4, 4}, {4, 5}}
+
 type FreePlayConfig struct {
-	TimerSeconds   int
-	MaxStrikes     int
-	NumFaces       int
-	ModulesPerFace int
+	TimerSeconds int
+	MaxStrikes   int
+	NumFaces     int
+	Rows         int
+	Columns      int
 
-	EnabledModules map[pb.Module_ModuleType]bool
+	ModuleCounts map[pb.Module_ModuleType]int
 }
 
 func DefaultFreePlayConfig() FreePlayConfig {
 	return FreePlayConfig{
-		TimerSeconds:   300,
-		MaxStrikes:     3,
-		NumFaces:       2,
-		ModulesPerFace: 6,
-		EnabledModules: map[pb.Module_ModuleType]bool{
-			pb.Module_WIRES:          true,
-			pb.Module_PASSWORD:       true,
-			pb.Module_BIG_BUTTON:     true,
-			pb.Module_SIMON:          true,
-			pb.Module_KEYPAD:         true,
-			pb.Module_WHOS_ON_FIRST:  true,
-			pb.Module_MEMORY:         true,
-			pb.Module_MORSE:          true,
-			pb.Module_MAZE:           true,
-			pb.Module_NEEDY_VENT_GAS: false,
-			pb.Module_NEEDY_KNOB:     false,
+		TimerSeconds: 300,
+		MaxStrikes:   3,
+		NumFaces:     2,
+		Rows:         2,
+		Columns:      3,
+		ModuleCounts: map[pb.Module_ModuleType]int{
+			pb.Module_WIRES:          1,
+			pb.Module_PASSWORD:       1,
+			pb.Module_BIG_BUTTON:     1,
+			pb.Module_SIMON:          1,
+			pb.Module_KEYPAD:         1,
+			pb.Module_WHOS_ON_FIRST:  1,
+			pb.Module_MEMORY:         1,
+			pb.Module_MORSE:          1,
+			pb.Module_MAZE:           1,
+			pb.Module_NEEDY_VENT_GAS: 0,
+			pb.Module_NEEDY_KNOB:     0,
 		},
 	}
 }
 
+// Capacity is the number of module slots on the bomb, less the one the
+// clock always takes. Faces on the largest grids hold no more modules than
+// the backend allows per face.
+func (c FreePlayConfig) Capacity() int {
+	return c.NumFaces*custombomb.PerFace(c.Rows, c.Columns) - 1
+}
+
+// stepFaceGrid moves to the next larger or smaller grid in faceGrids.
+func (c *FreePlayConfig) stepFaceGrid(delta int) {
+	for i, grid := range faceGrids {
+		if grid[0] == c.Rows && grid[1] == c.Columns {
+			next := faceGrids[min(max(i+delta, 0), len(faceGrids)-1)]
+			c.Rows, c.Columns = next[0], next[1]
+			return
+		}
+	}
+}
+
+func (c FreePlayConfig) TotalModules() int {
+	total := 0
+	for _, count := range c.ModuleCounts {
+		total += count
+	}
+	return total
+}
+
+// Validate reports why the bomb can't be built, or nil if it can.
+func (c FreePlayConfig) Validate() error {
+	solvable := 0
+	for moduleType, count := range c.ModuleCounts {
+		if !isNeedyModule(moduleType) {
+			solvable += count
+		}
+	}
+	if solvable == 0 {
+		return fmt.Errorf("add at least one module that can be solved")
+	}
+	if total, capacity := c.TotalModules(), c.Capacity(); total > capacity {
+		return fmt.Errorf("%d modules don't fit in %d slots; remove %d or add space", total, capacity, total-capacity)
+	}
+	if total, limit := c.TotalModules(), custombomb.MaxModules-1; total > limit {
+		return fmt.Errorf("bombs hold at most %d modules besides the clock; remove %d", limit, total-limit)
+	}
+	return custombomb.Validate(c.customBomb())
+}
+
+// customBomb is the config the backend builds the bomb from.
+func (c FreePlayConfig) customBomb() *pb.CustomBombConfig {
+	var modules []*pb.ModuleSpec
+	for _, moduleType := range freePlayModuleTypes {
+		if count := c.ModuleCounts[moduleType]; count > 0 {
+			modules = append(modules, &pb.ModuleSpec{
+				Type:  moduleType,
+				Count: int32(count),
+			})
+		}
+	}
+
+	// MinModules counts the clock, which the backend adds to the modules
+	// asked for.
+	return &pb.CustomBombConfig{
+		TimerSeconds:      int32(c.TimerSeconds),
+		MaxStrikes:        int32(c.MaxStrikes),
+		NumFaces:          int32(c.NumFaces),
+		Rows:              int32(c.Rows),
+		Columns:           int32(c.Columns),
+		MinModules:        int32(c.TotalModules() + 1),
+		Modules:           modules,
+		MaxModulesPerFace: int32(custombomb.PerFace(c.Rows, c.Columns)),
+	}
+}
+
 func joinStrings(strs []string, sep string) string {
 	if len(strs) == 0 {
 		return ""
@@ -103,8 +190,96 @@ func formatTimeString(seconds int) string {
 	return formatTwoDigits(mins) + ":" + formatTwoDigits(secs)
 }
 
-func hyperlink(url, text string) string {
-	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+// layout sizes the screen's boxes to the terminal.
+func (m *Model) layout() styles.Layout {
+	return styles.NewLayout(m.width, m.height)
+}
+
+// scrollRows fits rows, each of which may span several lines, into height
+// lines starting at line offset. Lines cut off above or below are replaced by
+// a marker counting them. A height of 0 shows every line.
+func scrollRows(rows []string, offset, height int) string {
+	lines, _ := splitRows(rows)
+	if height <= 0 || len(lines) <= height {
+		return strings.Join(lines, "\n")
+	}
+
+	// Both markers and at least one line.
+	height = max(height, 3)
+	offset = min(max(offset, 0), len(lines)-height+1)
+	end := offset + height - 1
+	if offset > 0 && end < len(lines) {
+		end--
+	}
+
+	var shown []string
+	if offset > 0 {
+		shown = append(shown, styles.Help.Render(fmt.Sprintf("↑ %d more", offset)))
+	}
+	shown = append(shown, lines[offset:end]...)
+	if end < len(lines) {
+		shown = append(shown, styles.Help.Render(fmt.Sprintf("↓ %d more", len(lines)-end)))
+	}
+	return strings.Join(shown, "\n")
+}
+
+// maxScrollOffset is the offset at which scrollRows shows the last line.
+func maxScrollOffset(rows []string, height int) int {
+	lines, _ := splitRows(rows)
+	if height <= 0 || len(lines) <= height {
+		return 0
+	}
+	return len(lines) - max(height, 3) + 1
+}
+
+// focusOffset is the offset for scrollRows that brings rows[focus] into
+// view, scrolling no further down than it has to.
+func focusOffset(rows []string, focus, height int) int {
+	lines, starts := splitRows(rows)
+	if height <= 0 || len(lines) <= height {
+		return 0
+	}
+	height = max(height, 3)
+
+	first, last := starts[focus], len(lines)-1
+	if focus+1 < len(rows) {
+		last = starts[focus+1] - 1
+	}
+	// Scrolled to the top, the last line is given to the marker below.
+	if last <= height-2 {
+		return 0
+	}
+	// Otherwise both markers take a line, except at the very bottom.
+	offset := min(last-height+3, first)
+	return min(offset, len(lines)-height+1)
+}
+
+// wrapRows wraps rows wider than width, as the content box would, so that
+// scrollRows counts the lines they really take up.
+func wrapRows(rows []string, width int) []string {
+	wrapped := make([]string, len(rows))
+	for i, row := range rows {
+		if lipgloss.Width(row) > width {
+			row = lipgloss.NewStyle().Width(width).Render(row)
+		}
+		wrapped[i] = row
+	}
+	return wrapped
+}
+
+// splitRows breaks rows into lines, along with the line each row starts on.
+func splitRows(rows []string) (lines []string, starts []int) {
+	for _, row := range rows {
+		starts = append(starts, len(lines))
+		lines = append(lines, strings.Split(row, "\n")...)
+	}
+	return lines, starts
+}
+
+// moduleSize is the size message modules are given in place of the
+// terminal's, so that they fit inside the content box.
+func (m *Model) moduleSize() tea.WindowSizeMsg {
+	return tea.WindowSizeMsg{Width: m.layout().TextWidth(), Height: m.height}
 }
 
 func (m *Model) renderFooter() string {
@@ -114,20 +289,29 @@ func (m *Model) renderFooter() string {
 		hint = "[↑/↓] Navigate  [ENTER] Select  [Q] Quit"
 	case StateSectionSelect:
 		hint = "[↑/↓] Navigate  [ENTER] Select section  [ESC] Back"
+		if m.profile != nil {
+			hint = "[↑/↓] Navigate  [ENTER] Select section  [C] Campaign  [ESC] Back"
+		}
 	case StateMissionSelect:
-		hint = "[↑/↓] Navigate  [ENTER] Start mission  [ESC] Back to sections"
+		hint = "[↑/↓] Navigate  [ENTER] Briefing  [ESC] Back to sections"
+	case StateMissionBriefing:
+		hint = "[↑/↓] Scroll  [ENTER] Start mission  [ESC] Back to missions"
 	case StateFreePlayMenu:
 		hint = "[↑/↓] Navigate  [ENTER] Select  [ESC] Back"
 	case StateFreePlayAdvanced:
 		hint = "[↑/↓] Navigate  [←/→] Adjust  [SPACE] Toggle  [ENTER] Start  [ESC] Back"
+	case StateDaily:
+		hint = "[↑/↓] Navigate  [ENTER] Select  [R] Refresh  [ESC] Back"
+	case StateLoadError:
+		hint = "[↑/↓] Navigate  [ENTER] Select  [R] Retry  [ESC] Back"
 	case StateBombSelection:
-		hint = "[ENTER] Pick up bomb | [↑/↓] Navigate | [Q]uit"
+		hint = "[ENTER] Pick up bomb | [↑/↓] Navigate | [^N] Notifications | [Q]uit"
 	case StateBombView:
 		hint = "[1-9] Select module | [<]/[>] Flip face | [ESC] Put down | [Q]uit"
 	case StateModuleActive:
 		hint = m.activeModule.Footer()
 	case StateGameOver:
-		hint = "[↑/↓] Navigate  [ENTER] Select"
+		hint = "[↑/↓] Navigate  [ENTER] Select  [E] Export JSON  [ESC] Menu"
 	}
-	return styles.FooterBox.Render(styles.Help.Render(hint))
+	return m.layout().Hint(hint)
 }
@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ZaneH/defuse.party-tui/internal/client/clienttest"
+	"github.com/ZaneH/defuse.party-tui/internal/profile"
+	"github.com/ZaneH/defuse.party-tui/internal/styles"
+	"github.com/ZaneH/defuse.party-tui/internal/tui/tuitest"
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+func TestCompactLayout(t *testing.T) {
+	store, err := profile.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	const date = "2026-10-16"
+	for i := range 12 {
+		id := fmt.Sprintf("user:%d", i)
+		if _, err := store.ClaimDaily(date, id, fmt.Sprintf("Player %d", i)); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.FinishDaily(date, id, "defused", time.Duration(i+1)*time.Minute, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	bomb := testBomb()
+	bomb.Ports = []pb.Port{pb.Port_RJ45, pb.Port_RCA, pb.Port_RCA, pb.Port_PS2, pb.Port_DVID, pb.Port_SERIAL}
+	m := newTestModel(clienttest.New(bomb))
+	m.attachProfile(store, nil)
+	d := tuitest.NewDriver(t, m).Send(tea.WindowSizeMsg{Width: 40, Height: 20})
+
+	// Every screen has to fit in the smallest supported terminal.
+	step := func(label string, keys ...string) {
+		t.Helper()
+		d.Type(keys...).Snapshot(label)
+		if rows := lipgloss.Height(m.View()); rows > styles.MinHeight {
+			t.Errorf("%s: view is %d rows tall, want at most %d", label, rows, styles.MinHeight)
+		}
+	}
+
+	step("mission briefing", "enter", "down", "down", "down", "enter", "down", "down", "down", "enter")
+	step("mission briefing scrolled", "pgdown")
+	step("daily bomb", "esc", "esc", "esc", "down", "down", "enter")
+	step("manual", "esc", "down", "down", "down", "enter")
+	step("manual scrolled", "pgdown", "pgdown")
+	step("free play menu", "esc", "up", "up", "enter")
+	step("advanced settings", "down", "down", "down", "down", "enter")
+	step("advanced settings modules", "down", "down", "down", "down", "down", "down", "down", "down", "down", "down")
+	step("bomb selection", "esc", "up", "up", "up", "up", "enter")
+	step("bomb view", "enter")
+	step("wires module", "2")
+	d.Send(tea.WindowSizeMsg{Width: 39, Height: 20}).Snapshot("too narrow").
+		Send(tea.WindowSizeMsg{Width: 60, Height: 19}).Snapshot("too short").
+		Send(tea.WindowSizeMsg{Width: 80, Height: 40}).Snapshot("full size").
+		RequireGolden()
+}
+
+func TestAbbreviatePorts(t *testing.T) {
+	ports := []string{"RJ45", "RCA", "RCA", "PS2", "DVI"}
+	tests := []struct {
+		width int
+		want  string
+	}{
+		{40, "P: RJ45 RCA×2 PS2 DVI"},
+		{17, "P: RJ45 RCA×2 +2"},
+		{9, "P: +4"},
+	}
+	for _, tt := range tests {
+		if got := abbreviatePorts(ports, tt.width); got != tt.want {
+			t.Errorf("abbreviatePorts(%d) = %q, want %q", tt.width, got, tt.want)
+		}
+	}
+	if got := abbreviatePorts(nil, 10); got != "P: -" {
+		t.Errorf("abbreviatePorts(nil) = %q", got)
+	}
+}
+
+func TestScrollRows(t *testing.T) {
+	rows := []string{"a", "b\nc", "d", "e", "f", "g"}
+	tests := []struct {
+		offset, height int
+		want           string
+	}{
+		{0, 0, "a\nb\nc\nd\ne\nf\ng"},
+		{0, 10, "a\nb\nc\nd\ne\nf\ng"},
+		{0, 4, "a\nb\nc\n↓ 4 more"},
+		{2, 4, "↑ 2 more\nc\nd\n↓ 3 more"},
+		{9, 4, "↑ 4 more\ne\nf\ng"},
+	}
+	for _, tt := range tests {
+		if got := scrollRows(rows, tt.offset, tt.height); got != tt.want {
+			t.Errorf("scrollRows(%d, %d) = %q, want %q", tt.offset, tt.height, got, tt.want)
+		}
+	}
+
+	// The focused row is scrolled into view, and no further.
+	for focus, want := range []int{0, 0, 2, 3, 4, 4} {
+		if got := focusOffset(rows, focus, 4); got != want {
+			t.Errorf("focusOffset(%d) = %d, want %d", focus, got, want)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ZaneH/defuse.party-tui/internal/client"
+	"github.com/ZaneH/defuse.party-tui/internal/styles"
+)
+
+const spinnerInterval = 100 * time.Millisecond
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+type LoadErrorOption int
+
+const (
+	LoadErrorRetry LoadErrorOption = iota
+	LoadErrorBack
+	LoadErrorQuit
+)
+
+var loadErrorOptions = []string{
+	"RETRY",
+	"BACK TO MENU",
+	"QUIT",
+}
+
+func (m *Model) loadingTick() tea.Cmd {
+	loadID := m.loadID
+	return tea.Tick(spinnerInterval, func(time.Time) tea.Msg {
+		return loadingTickMsg{loadID: loadID}
+	})
+}
+
+func (m *Model) cancelLoading() {
+	if m.loadCancel != nil {
+		m.loadCancel()
+		m.loadCancel = nil
+	}
+}
+
+// leaveLoading abandons the game being loaded and returns to the menu it was
+// started from.
+func (m *Model) leaveLoading() {
+	m.cancelLoading()
+	m.loadErr = nil
+	switch m.loadOrigin {
+	case StateSectionSelect, StateMissionSelect, StateMissionBriefing, StateFreePlayMenu, StateFreePlayAdvanced, StateDaily:
+		m.state = m.loadOrigin
+	default:
+		m.resetToMainMenu()
+	}
+}
+
+func (m *Model) handleLoadingKeys(key string) (tea.Cmd, bool) {
+	if key != "esc" {
+		return nil, false
+	}
+	m.leaveLoading()
+	return nil, true
+}
+
+func (m *Model) handleLoadErrorKeys(key string) (tea.Cmd, bool) {
+	handled := true
+	switch key {
+	case "up", "k":
+		if m.loadErrorSelection > 0 {
+			m.loadErrorSelection--
+		}
+	case "down", "j":
+		if m.loadErrorSelection < len(loadErrorOptions)-1 {
+			m.loadErrorSelection++
+		}
+	case "r", "R":
+		return m.StartGame(m.pendingGameConfig), true
+	case "esc":
+		m.leaveLoading()
+	case "enter":
+		switch LoadErrorOption(m.loadErrorSelection) {
+		case LoadErrorRetry:
+			return m.StartGame(m.pendingGameConfig), true
+		case LoadErrorBack:
+			m.leaveLoading()
+		case LoadErrorQuit:
+			return tea.Quit, true
+		}
+	default:
+		handled = false
+	}
+	return nil, handled
+}
+
+func (m *Model) loadingView() string {
+	elapsed := m.now().Sub(m.loadStartedAt)
+	frame := spinnerFrames[int(elapsed/spinnerInterval)%len(spinnerFrames)]
+
+	return styles.Center(
+		lipgloss.JoinVertical(
+			lipgloss.Center,
+			styles.Title.Render("DEFUSE.PARTY"),
+			"",
+			styles.Subtitle.Render(frame+" Creating game... "+formatTimer(elapsed)),
+			"",
+			styles.Help.Render("[ESC] Cancel"),
+		),
+		m.width, m.height,
+	)
+}
+
+func (m *Model) loadErrorView() string {
+	var details []string
+	if m.loadErr != nil {
+		cause := client.Describe(m.loadErr)
+		details = append(details, styles.Error.Render(cause))
+		if full := m.loadErr.Error(); full != cause {
+			details = append(details, styles.Help.Width(min(60, m.layout().TextWidth())).Align(lipgloss.Center).Render(full))
+		}
+	}
+
+	var optionLines []string
+	for i, opt := range loadErrorOptions {
+		if i == m.loadErrorSelection {
+			optionLines = append(optionLines, styles.Active.Render("> "+opt))
+		} else {
+			optionLines = append(optionLines, "  "+opt)
+		}
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Center,
+		styles.Title.Render("COULDN'T START GAME"),
+		"",
+		lipgloss.JoinVertical(lipgloss.Center, details...),
+		"",
+		"",
+		lipgloss.JoinVertical(lipgloss.Center, optionLines...),
+	)
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		m.layout().Header().Render(styles.Title.Render("DEFUSE.PARTY")),
+		m.layout().Content().Render(content),
+		m.renderFooter(),
+	)
+}
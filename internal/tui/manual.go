@@ -0,0 +1,307 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/ZaneH/defuse.party-tui/internal/manual"
+	"github.com/ZaneH/defuse.party-tui/internal/styles"
+)
+
+const (
+	// manualPaneWidth is the width of the side pane, border included, and
+	// splitManualWidth the terminal width needed to fit it beside a module.
+	manualPaneWidth  = 70
+	splitManualWidth = styles.MaxWidth + 1 + manualPaneWidth
+)
+
+// manualPager is the built-in defusal manual. It opens over any screen, so a
+// game keeps running underneath while it is read.
+type manualPager struct {
+	open   bool
+	page   int
+	scroll int
+
+	searching bool
+	query     string
+	matches   []manual.Match
+	match     int
+
+	// paneScroll is the position of the side pane shown next to the active
+	// module on wide terminals. It starts at the top for every module opened.
+	paneScroll int
+}
+
+// openManual shows the manual, turning to the active module's page if there
+// is one. Otherwise it opens where the player last left it.
+func (m *Model) openManual() {
+	m.manual.open = true
+	m.manual.searching = false
+	if m.state == StateModuleActive && m.activeModule != nil {
+		if i, ok := manual.Index(m.activeModule.ModuleType()); ok && i != m.manual.page {
+			m.manual.page = i
+			m.manual.scroll = 0
+		}
+	}
+}
+
+// manualLine is a line of the open page as it is shown, wrapped to the
+// content box. source is the page line it came from.
+type manualLine struct {
+	text   string
+	source int
+}
+
+func (m *Model) manualLines() []manualLine {
+	width := m.layout().TextWidth()
+	var lines []manualLine
+	for i, line := range manual.Pages()[m.manual.page].Lines {
+		// Continuation lines keep the line's indent.
+		indent := line[:len(line)-len(strings.TrimLeft(line, " "))]
+		wrapped := strings.Split(ansi.Wrap(strings.TrimLeft(line, " "), width-len(indent), ""), "\n")
+		for _, text := range wrapped {
+			lines = append(lines, manualLine{text: indent + text, source: i})
+		}
+	}
+	return lines
+}
+
+// manualRows is how many lines of the page fit on screen.
+func (m *Model) manualRows() int {
+	if m.height == 0 {
+		return 20
+	}
+	// Leave room for the longest position the header can show.
+	lines := len(m.manualLines())
+	pages := len(manual.Pages())
+	position := fmt.Sprintf("%d/%d  lines %d-%d of %d", pages, pages, lines, lines, lines)
+	return max(m.layout().BodyRows(m.manualHeader(position), m.manualFooter()), 3)
+}
+
+func (m *Model) scrollManual(delta int) {
+	lines := len(m.manualLines())
+	m.manual.scroll = min(max(m.manual.scroll+delta, 0), max(lines-m.manualRows(), 0))
+}
+
+func (m *Model) turnManualPage(delta int) {
+	count := len(manual.Pages())
+	m.manual.page = (m.manual.page + delta + count) % count
+	m.manual.scroll = 0
+}
+
+// showManualMatch turns to the current search match and scrolls it into view
+// with a little context above it.
+func (m *Model) showManualMatch() {
+	if len(m.manual.matches) == 0 {
+		return
+	}
+	match := m.manual.matches[m.manual.match]
+	m.manual.page = match.Page
+	m.manual.scroll = 0
+	for i, line := range m.manualLines() {
+		if line.source == match.Line {
+			m.scrollManual(i - 2)
+			break
+		}
+	}
+}
+
+func (m *Model) handleManualKeys(msg tea.KeyMsg) tea.Cmd {
+	if msg.Type == tea.KeyCtrlC {
+		m.closeGameClient()
+		return tea.Quit
+	}
+	if m.manual.searching {
+		m.handleManualSearchKeys(msg)
+		return nil
+	}
+
+	switch msg.String() {
+	case "esc", "q", "?":
+		m.manual.open = false
+	case "up", "k":
+		m.scrollManual(-1)
+	case "down", "j":
+		m.scrollManual(1)
+	case "pgup", "b":
+		m.scrollManual(-m.manualRows())
+	case "pgdown", " ":
+		m.scrollManual(m.manualRows())
+	case "left", "h", "shift+tab":
+		m.turnManualPage(-1)
+	case "right", "l", "tab":
+		m.turnManualPage(1)
+	case "/":
+		m.manual.searching = true
+		m.manual.query = ""
+	case "n":
+		if len(m.manual.matches) > 0 {
+			m.manual.match = (m.manual.match + 1) % len(m.manual.matches)
+			m.showManualMatch()
+		}
+	case "N":
+		if len(m.manual.matches) > 0 {
+			m.manual.match = (m.manual.match - 1 + len(m.manual.matches)) % len(m.manual.matches)
+			m.showManualMatch()
+		}
+	}
+	return nil
+}
+
+func (m *Model) handleManualSearchKeys(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.manual.searching = false
+	case tea.KeyBackspace:
+		if runes := []rune(m.manual.query); len(runes) > 0 {
+			m.manual.query = string(runes[:len(runes)-1])
+		}
+	case tea.KeyEnter:
+		m.manual.searching = false
+		m.manual.matches = manual.Search(m.manual.query)
+		m.manual.match = 0
+		m.showManualMatch()
+	case tea.KeySpace:
+		m.manual.query += " "
+	case tea.KeyRunes:
+		for _, r := range msg.Runes {
+			if unicode.IsPrint(r) {
+				m.manual.query += string(r)
+			}
+		}
+	}
+}
+
+func (m *Model) manualView() string {
+	pages := manual.Pages()
+
+	// Lines matching the last search are highlighted, the current one most.
+	highlight := make(map[int]lipgloss.Style)
+	for i, match := range m.manual.matches {
+		if match.Page != m.manual.page || match.Line < 0 {
+			continue
+		}
+		if i == m.manual.match {
+			highlight[match.Line] = styles.Warning
+		} else if _, ok := highlight[match.Line]; !ok {
+			highlight[match.Line] = styles.Active
+		}
+	}
+
+	lines := m.manualLines()
+	end := min(m.manual.scroll+m.manualRows(), len(lines))
+	var body []string
+	for _, line := range lines[m.manual.scroll:end] {
+		text := line.text
+		if style, ok := highlight[line.source]; ok {
+			text = style.Render(text)
+		}
+		body = append(body, text)
+	}
+
+	position := fmt.Sprintf("%d/%d", m.manual.page+1, len(pages))
+	if len(lines) > m.manualRows() {
+		position += fmt.Sprintf("  lines %d-%d of %d", m.manual.scroll+1, end, len(lines))
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		m.manualHeader(position),
+		m.layout().Content().Render(lipgloss.JoinVertical(lipgloss.Left, body...)),
+		m.manualFooter(),
+	)
+}
+
+func (m *Model) manualHeader(position string) string {
+	header := lipgloss.JoinHorizontal(lipgloss.Top,
+		styles.Title.Render("MANUAL: "+manual.Pages()[m.manual.page].Title),
+		"  ",
+		styles.Help.Render(position),
+	)
+	// The bomb keeps ticking while the manual covers it.
+	if m.inGame() {
+		header = lipgloss.JoinHorizontal(lipgloss.Top, header, "  ", styles.Warning.Render(formatTimer(m.timeRemaining())))
+	}
+	return m.layout().Header().Render(header)
+}
+
+func (m *Model) manualFooter() string {
+	layout := m.layout()
+	switch {
+	case m.manual.searching:
+		return layout.Footer().Render("Search: " + styles.Active.Render(m.manual.query+"_") + styles.Help.Render("  [ENTER] Find  [ESC] Cancel"))
+	case m.manual.query != "" && len(m.manual.matches) == 0:
+		return layout.Footer().Render(styles.Warning.Render(fmt.Sprintf("No matches for %q", m.manual.query)) +
+			styles.Help.Render("  [/] Search  [ESC] Close"))
+	case len(m.manual.matches) > 0:
+		return layout.Hint(fmt.Sprintf("Match %d/%d  [N/n] Prev/next  [/] Search  [←/→] Page  [ESC] Close",
+			m.manual.match+1, len(m.manual.matches)))
+	}
+	return layout.Hint("[↑/↓] Scroll  [←/→] Page  [/] Search  [ESC] Close")
+}
+
+// manualPanePage is the page to show beside the active module, if the
+// terminal is wide enough and the module has one.
+func (m *Model) manualPanePage() (manual.Page, bool) {
+	if m.state != StateModuleActive || m.activeModule == nil || m.width < splitManualWidth {
+		return manual.Page{}, false
+	}
+	return manual.Lookup(m.activeModule.ModuleType())
+}
+
+// manualPaneRows is how many lines of the page fit in the side pane, which
+// runs the full height of the terminal.
+func (m *Model) manualPaneRows() int {
+	// Border and padding take four rows; the title and hint two more each.
+	return max(m.height-8, 3)
+}
+
+// scrollManualPane moves the side pane by half its height in direction. It
+// reports false if there is no pane to scroll.
+func (m *Model) scrollManualPane(direction int) bool {
+	page, ok := m.manualPanePage()
+	if !ok {
+		return false
+	}
+	rows := m.manualPaneRows()
+	scroll := m.manual.paneScroll + direction*max(rows/2, 1)
+	m.manual.paneScroll = min(max(scroll, 0), max(len(page.Lines)-rows, 0))
+	return true
+}
+
+// withManualPane puts the active module's manual page to the right of view.
+func (m *Model) withManualPane(view string) string {
+	page, ok := m.manualPanePage()
+	if !ok {
+		return view
+	}
+
+	rows := m.manualPaneRows()
+	start := min(m.manual.paneScroll, max(len(page.Lines)-rows, 0))
+	end := min(start+rows, len(page.Lines))
+
+	hint := ""
+	if len(page.Lines) > rows {
+		hint = fmt.Sprintf("lines %d-%d of %d  [PGUP/PGDN] Scroll", start+1, end, len(page.Lines))
+	}
+	pane := lipgloss.JoinVertical(
+		lipgloss.Left,
+		styles.Title.Render("MANUAL: "+page.Title),
+		"",
+		lipgloss.JoinVertical(lipgloss.Left, page.Lines[start:end]...),
+	)
+	pane = lipgloss.NewStyle().Height(rows + 2).Render(pane)
+	pane = lipgloss.JoinVertical(lipgloss.Left, pane, "", styles.Help.Render(hint))
+
+	return lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		view,
+		" ",
+		styles.ContentBox.Width(manualPaneWidth-2).Render(pane),
+	)
+}
@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ZaneH/defuse.party-tui/internal/client/clienttest"
+	"github.com/ZaneH/defuse.party-tui/internal/manual"
+	"github.com/ZaneH/defuse.party-tui/internal/tui/tuitest"
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+func TestManualPager(t *testing.T) {
+	c := clienttest.New(testBomb())
+	d := newTestDriver(t, c)
+	m := d.Model().(*Model)
+
+	d.Type("down", "down", "down", "enter").Snapshot("first page").
+		Type("right", "down").Snapshot("next page").
+		Type("/", "vent", " ", "gas", "enter").Snapshot("search")
+	if page := manual.Pages()[m.manual.page]; page.Type != pb.Module_NEEDY_VENT_GAS {
+		t.Errorf("search turned to %s, want Vent Gas", page.Title)
+	}
+
+	d.Type("esc")
+	if m.manual.open || m.state != StateMainMenu {
+		t.Fatalf("esc left the manual open in state %v", m.state)
+	}
+
+	// In a game the manual opens on the active module's page and the game
+	// carries on underneath.
+	d.Type("up", "up", "enter", "enter", "enter", "2", "?").Snapshot("active module").RequireGolden()
+	if page := manual.Pages()[m.manual.page]; page.Type != pb.Module_WIRES {
+		t.Errorf("manual opened on %s, want Wires", page.Title)
+	}
+	d.Type("esc")
+	if m.manual.open || m.state != StateModuleActive {
+		t.Errorf("closing the manual left state %v, want the wires module", m.state)
+	}
+}
+
+func TestManualPane(t *testing.T) {
+	c := clienttest.New(testBomb())
+	m := newTestModel(c)
+	d := tuitest.NewDriver(t, m).Send(tea.WindowSizeMsg{Width: splitManualWidth, Height: 30})
+
+	d.Type("down", "enter", "enter", "enter", "2").Snapshot("wide").
+		Type("pgdown").Snapshot("scrolled").
+		RequireGolden()
+
+	// Reopening a module starts its page from the top again.
+	d.Type("esc", "2")
+	if m.manual.paneScroll != 0 {
+		t.Errorf("pane scroll = %d after reopening the module, want 0", m.manual.paneScroll)
+	}
+
+	d.Send(tea.WindowSizeMsg{Width: splitManualWidth - 1, Height: 30})
+	if strings.Contains(m.View(), "MANUAL: Wires") {
+		t.Error("manual pane shown on a narrow terminal")
+	}
+}
@@ -1,6 +1,8 @@
 package tui
 
 import (
+	"strings"
+
 	"github.com/ZaneH/defuse.party-tui/internal/styles"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -11,20 +13,27 @@ type MenuItem int
 const (
 	MenuPlayGame MenuItem = iota
 	MenuFreePlay
+	MenuDaily
 	MenuManual
+	MenuTheme
 	MenuQuit
 )
 
 var menuItems = []string{
 	"PLAY GAME",
 	"FREE PLAY",
+	"DAILY BOMB",
 	"MANUAL",
+	"THEME",
 	"QUIT",
 }
 
 func (m *Model) mainMenuView() string {
 	var items []string
 	for i, item := range menuItems {
+		if MenuItem(i) == MenuTheme {
+			item += ": " + strings.ToUpper(m.theme.Name)
+		}
 		if i == m.menuSelection {
 			items = append(items, styles.Active.Render("> "+item))
 		} else {
@@ -32,11 +41,14 @@ func (m *Model) mainMenuView() string {
 		}
 	}
 
+	header := []string{styles.Title.Render("DEFUSE.PARTY"), ""}
+	if m.profile != nil && !m.profile.IsNew() {
+		header = append(header, styles.Subtitle.Render("Playing as "+m.profile.Nickname), "")
+	}
+
 	menuContent := lipgloss.JoinVertical(
 		lipgloss.Center,
-		styles.Title.Render("DEFUSE.PARTY"),
-		"",
-		lipgloss.JoinVertical(lipgloss.Left, items...),
+		append(header, lipgloss.JoinVertical(lipgloss.Left, items...))...,
 	)
 
 	return styles.Center(
@@ -67,11 +79,24 @@ func (m *Model) handleMainMenuKeys(key string) (tea.Cmd, bool) {
 		case MenuFreePlay:
 			m.state = StateFreePlayMenu
 			m.freePlaySelection = 0
+		case MenuDaily:
+			return m.openDaily(), true
 		case MenuManual:
-			m.showManualDialog = true
+			m.openManual()
+		case MenuTheme:
+			return m.setTheme(m.theme.Next(1)), true
 		case MenuQuit:
 			return tea.Quit, true
 		}
+	case "left", "h", "right", "l":
+		if MenuItem(m.menuSelection) != MenuTheme {
+			return nil, false
+		}
+		delta := 1
+		if key == "left" || key == "h" {
+			delta = -1
+		}
+		return m.setTheme(m.theme.Next(delta)), true
 	case "q":
 		return tea.Quit, true
 	default:
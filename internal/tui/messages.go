@@ -4,18 +4,28 @@ import (
 	"time"
 
 	"github.com/ZaneH/defuse.party-tui/internal/client"
+	"github.com/ZaneH/defuse.party-tui/internal/profile"
 	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
 )
 
-type loadingErrorMsg struct{ err error }
+type loadingErrorMsg struct {
+	loadID int
+	err    error
+}
+
+type loadingTickMsg struct{ loadID int }
 
 type gameReadyMsg struct {
+	loadID    int
 	client    client.GameClient
 	sessionID string
 	bombs     []*pb.Bomb
 }
 
-type tickMsg struct{ t time.Time }
+type tickMsg struct {
+	t      time.Time
+	gameID int
+}
 
 type startGameMsg struct {
 	config *pb.GameConfig
@@ -24,3 +34,41 @@ type startGameMsg struct {
 type returnToMenuMsg struct{}
 
 type showManualMsg struct{}
+
+type bombsSyncedMsg struct {
+	gameID int
+	bombs  []*pb.Bomb
+	err    error
+}
+
+type toastExpiredMsg struct{ id int }
+
+type nicknameSavedMsg struct {
+	profile *profile.Profile
+	err     error
+}
+
+// profileUpdatedMsg carries the stored profile after a write. failure is
+// shown to the player if the write didn't go through.
+type profileUpdatedMsg struct {
+	profile *profile.Profile
+	err     error
+	failure string
+}
+
+type dailyBoardMsg struct {
+	date    string
+	entries []profile.DailyEntry
+	err     error
+}
+
+// dailyClaimedMsg reports whether the game gameID is a ranked daily attempt.
+type dailyClaimedMsg struct {
+	gameID int
+	ranked bool
+	err    error
+}
+
+type dailyFinishedMsg struct{ err error }
+
+type reportCopiedMsg struct{ err error }
@@ -0,0 +1,150 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ZaneH/defuse.party-tui/internal/styles"
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+func (m *Model) selectedMission() MissionInfo {
+	return m.missionSections[m.sectionSelection].Missions[m.missionSelection]
+}
+
+func (g MissionModule) needy() bool {
+	if len(g.Types) == 0 {
+		return false
+	}
+	for _, t := range g.Types {
+		if !strings.HasPrefix(t, "NEEDY_") {
+			return false
+		}
+	}
+	return true
+}
+
+// ModuleCount splits the mission's modules into those that must be solved
+// and needy ones.
+func (mi MissionInfo) ModuleCount() (regular, needy int) {
+	for _, group := range mi.Modules {
+		if group.needy() {
+			needy += group.Count
+		} else {
+			regular += group.Count
+		}
+	}
+	return regular, needy
+}
+
+func (m *Model) missionModuleName(group MissionModule) string {
+	if len(group.Types) == 0 {
+		return "ANY MODULE"
+	}
+	names := make([]string, len(group.Types))
+	for i, t := range group.Types {
+		if v, ok := pb.Module_ModuleType_value[t]; ok {
+			names[i] = m.moduleTypeName(pb.Module_ModuleType(v))
+		} else {
+			// Types the backend has but this client can't render yet.
+			names[i] = strings.ReplaceAll(t, "_", " ")
+		}
+	}
+	return strings.Join(names, " or ")
+}
+
+func (m *Model) handleMissionBriefingKeys(key string) (tea.Cmd, bool) {
+	handled := true
+	switch key {
+	case "up", "k":
+		m.scrollBriefing(-1)
+	case "down", "j":
+		m.scrollBriefing(1)
+	case "pgup":
+		m.scrollBriefing(-m.briefingRows())
+	case "pgdown", " ":
+		m.scrollBriefing(m.briefingRows())
+	case "enter":
+		return m.StartGame(m.selectedMission().gameConfig()), true
+	case "esc":
+		m.state = StateMissionSelect
+	default:
+		handled = false
+	}
+	return nil, handled
+}
+
+func (m *Model) briefingHeader() string {
+	return m.layout().Header().Render(styles.Title.Render("DEFUSE.PARTY"))
+}
+
+// briefingRows is how many lines of the briefing fit on screen.
+func (m *Model) briefingRows() int {
+	return m.layout().BodyRows(m.briefingHeader(), m.renderFooter())
+}
+
+func (m *Model) scrollBriefing(delta int) {
+	limit := maxScrollOffset(m.missionBriefingRows(), m.briefingRows())
+	m.briefingScroll = min(max(m.briefingScroll+delta, 0), limit)
+}
+
+func (m *Model) missionBriefingView() string {
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		m.briefingHeader(),
+		m.layout().Content().Render(scrollRows(m.missionBriefingRows(), m.briefingScroll, m.briefingRows())),
+		m.renderFooter(),
+	)
+}
+
+func (m *Model) missionBriefingRows() []string {
+	mission := m.selectedMission()
+	regular, needy := mission.ModuleCount()
+
+	faces := "1 face"
+	if mission.Faces != 1 {
+		faces = fmt.Sprintf("%d faces", mission.Faces)
+	}
+	stats := []string{
+		fmt.Sprintf("Timer:          %s", formatTimer(mission.Timer)),
+		fmt.Sprintf("Strike limit:   %d", mission.MaxStrikes),
+		fmt.Sprintf("Modules:        %d on %s", regular, faces),
+	}
+
+	var modules, needyModules []string
+	for _, group := range mission.Modules {
+		line := fmt.Sprintf("  %2d× %s", group.Count, m.missionModuleName(group))
+		if group.needy() {
+			needyModules = append(needyModules, styles.Warning.Render(line))
+		} else {
+			modules = append(modules, line)
+		}
+	}
+
+	rows := []string{
+		styles.Title.Render(mission.Name),
+		styles.Help.Render(m.missionSections[m.sectionSelection].Name),
+		"",
+		lipgloss.NewStyle().Width(min(56, m.layout().TextWidth())).Render(mission.Description),
+		"",
+		lipgloss.JoinVertical(lipgloss.Left, stats...),
+		"",
+		styles.Subtitle.Render("MODULES"),
+		lipgloss.JoinVertical(lipgloss.Left, modules...),
+	}
+	if needy > 0 {
+		rows = append(rows,
+			"",
+			styles.Subtitle.Render(fmt.Sprintf("NEEDY (%d)", needy)),
+			lipgloss.JoinVertical(lipgloss.Left, needyModules...),
+		)
+	}
+	if result := m.missionResult(mission); result.Completed {
+		rows = append(rows, "", styles.Success.Render(fmt.Sprintf(
+			"✓ Best time %s, fewest strikes %d", formatTimer(result.BestTime), result.FewestStrikes)))
+	}
+	return wrapRows(rows, m.layout().TextWidth())
+}
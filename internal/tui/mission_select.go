@@ -1,6 +1,10 @@
 package tui
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/ZaneH/defuse.party-tui/internal/profile"
 	"github.com/ZaneH/defuse.party-tui/internal/styles"
 	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
 	tea "github.com/charmbracelet/bubbletea"
@@ -12,114 +16,574 @@ type MissionSection struct {
 	Missions []MissionInfo
 }
 
+// MissionInfo describes a mission for its briefing. Built-in missions are
+// backend presets, so their details mirror its mission definitions; missions
+// from a catalog file may instead carry a Custom bomb, identified by ID.
 type MissionInfo struct {
-	Name    string
-	Mission pb.Mission
+	Name        string
+	Mission     pb.Mission
+	ID          string
+	Custom      *pb.CustomBombConfig
+	Description string
+	Timer       time.Duration
+	MaxStrikes  int
+	Faces       int
+	Modules     []MissionModule
+}
+
+// key identifies the mission in player profiles.
+func (mi MissionInfo) key() string {
+	if mi.Custom != nil {
+		return "custom:" + mi.ID
+	}
+	return mi.Mission.String()
 }
 
-var missionSections = []MissionSection{
+func (mi MissionInfo) gameConfig() *pb.GameConfig {
+	if mi.Custom != nil {
+		return &pb.GameConfig{ConfigType: &pb.GameConfig_Custom{Custom: mi.Custom}}
+	}
+	return &pb.GameConfig{
+		ConfigType: &pb.GameConfig_Preset{
+			Preset: &pb.PresetMissionConfig{
+				Mission: mi.Mission,
+			},
+		},
+	}
+}
+
+// MissionModule is a group of identical module slots. Types holds backend
+// module type names: one means a fixed type, several mean one of them is
+// picked, and none means any module from the section's pool.
+type MissionModule struct {
+	Types []string
+	Count int
+}
+
+var builtinMissionSections = []MissionSection{
 	{
 		Name: "Section 1: Introduction",
 		Missions: []MissionInfo{
-			{Name: "The First Bomb", Mission: pb.Mission_THE_FIRST_BOMB},
+			{
+				Name:        "The First Bomb",
+				Mission:     pb.Mission_THE_FIRST_BOMB,
+				Description: "A simple bomb to get you started. Talk it through and take your time.",
+				Timer:       5 * time.Minute,
+				MaxStrikes:  3,
+				Faces:       1,
+				Modules: []MissionModule{
+					{Types: []string{"BIG_BUTTON"}, Count: 1},
+					{Types: []string{"KEYPAD"}, Count: 1},
+					{Types: []string{"WIRES"}, Count: 1},
+				},
+			},
 		},
 	},
 	{
 		Name: "Section 2: The Basics",
 		Missions: []MissionInfo{
-			{Name: "Something Old, Something New", Mission: pb.Mission_SOMETHING_OLD_SOMETHING_NEW},
-			{Name: "Double Your Money", Mission: pb.Mission_DOUBLE_YOUR_MONEY},
-			{Name: "One Step Up", Mission: pb.Mission_ONE_STEP_UP},
-			{Name: "Pick Up The Pace", Mission: pb.Mission_PICK_UP_THE_PACE},
+			{
+				Name:        "Something Old, Something New",
+				Mission:     pb.Mission_SOMETHING_OLD_SOMETHING_NEW,
+				Description: "Familiar modules, plus one you may not have seen before.",
+				Timer:       5 * time.Minute,
+				MaxStrikes:  3,
+				Faces:       1,
+				Modules: []MissionModule{
+					{Types: []string{"KEYPAD"}, Count: 1},
+					{Types: []string{"WIRES"}, Count: 1},
+					{Count: 1},
+				},
+			},
+			{
+				Name:        "Double Your Money",
+				Mission:     pb.Mission_DOUBLE_YOUR_MONEY,
+				Description: "Twice the modules you've already met. Stay organised.",
+				Timer:       5 * time.Minute,
+				MaxStrikes:  3,
+				Faces:       1,
+				Modules: []MissionModule{
+					{Types: []string{"BIG_BUTTON"}, Count: 2},
+					{Types: []string{"KEYPAD"}, Count: 2},
+					{Types: []string{"WIRES"}, Count: 2},
+				},
+			},
+			{
+				Name:        "One Step Up",
+				Mission:     pb.Mission_ONE_STEP_UP,
+				Description: "Four modules drawn from everything you've learned so far.",
+				Timer:       5 * time.Minute,
+				MaxStrikes:  3,
+				Faces:       1,
+				Modules: []MissionModule{
+					{Count: 4},
+				},
+			},
+			{
+				Name:        "Pick Up The Pace",
+				Mission:     pb.Mission_PICK_UP_THE_PACE,
+				Description: "A short fuse. Work quickly and communicate clearly.",
+				Timer:       3 * time.Minute,
+				MaxStrikes:  3,
+				Faces:       1,
+				Modules: []MissionModule{
+					{Count: 3},
+				},
+			},
 		},
 	},
 	{
 		Name: "Section 3: Moderate",
 		Missions: []MissionInfo{
-			{Name: "A Hidden Message", Mission: pb.Mission_A_HIDDEN_MESSAGE},
-			{Name: "Something's Different", Mission: pb.Mission_SOMETHINGS_DIFFERENT},
-			{Name: "One Giant Leap", Mission: pb.Mission_ONE_GIANT_LEAP},
-			{Name: "Fair Game", Mission: pb.Mission_FAIR_GAME},
-			{Name: "Pick Up The Pace II", Mission: pb.Mission_PICK_UP_THE_PACE_II},
-			{Name: "No Room For Error", Mission: pb.Mission_NO_ROOM_FOR_ERROR},
-			{Name: "Eight Minutes", Mission: pb.Mission_EIGHT_MINUTES},
+			{
+				Name:        "A Hidden Message",
+				Mission:     pb.Mission_A_HIDDEN_MESSAGE,
+				Description: "Somewhere on this bomb is a word waiting to be found.",
+				Timer:       5 * time.Minute,
+				MaxStrikes:  3,
+				Faces:       1,
+				Modules: []MissionModule{
+					{Types: []string{"MORSE", "PASSWORD"}, Count: 1},
+					{Count: 2},
+				},
+			},
+			{
+				Name:        "Something's Different",
+				Mission:     pb.Mission_SOMETHINGS_DIFFERENT,
+				Description: "These wires don't follow the rules you know.",
+				Timer:       5 * time.Minute,
+				MaxStrikes:  3,
+				Faces:       1,
+				Modules: []MissionModule{
+					{Types: []string{"COMPLICATED_WIRES", "WIRE_SEQUENCE"}, Count: 1},
+					{Count: 2},
+				},
+			},
+			{
+				Name:        "One Giant Leap",
+				Mission:     pb.Mission_ONE_GIANT_LEAP,
+				Description: "Four modules from a much larger pool.",
+				Timer:       5 * time.Minute,
+				MaxStrikes:  3,
+				Faces:       1,
+				Modules: []MissionModule{
+					{Count: 4},
+				},
+			},
+			{
+				Name:        "Fair Game",
+				Mission:     pb.Mission_FAIR_GAME,
+				Description: "Five modules, anything goes.",
+				Timer:       5 * time.Minute,
+				MaxStrikes:  3,
+				Faces:       1,
+				Modules: []MissionModule{
+					{Count: 5},
+				},
+			},
+			{
+				Name:        "Pick Up The Pace II",
+				Mission:     pb.Mission_PICK_UP_THE_PACE_II,
+				Description: "Five modules and barely enough time for them.",
+				Timer:       2*time.Minute + 30*time.Second,
+				MaxStrikes:  3,
+				Faces:       1,
+				Modules: []MissionModule{
+					{Count: 5},
+				},
+			},
+			{
+				Name:        "No Room For Error",
+				Mission:     pb.Mission_NO_ROOM_FOR_ERROR,
+				Description: "A single strike sets it off.",
+				Timer:       5 * time.Minute,
+				MaxStrikes:  1,
+				Faces:       1,
+				Modules: []MissionModule{
+					{Count: 5},
+				},
+			},
+			{
+				Name:        "Eight Minutes",
+				Mission:     pb.Mission_EIGHT_MINUTES,
+				Description: "A two-sided bomb with eight modules. Don't forget to turn it over.",
+				Timer:       8 * time.Minute,
+				MaxStrikes:  3,
+				Faces:       2,
+				Modules: []MissionModule{
+					{Count: 8},
+				},
+			},
 		},
 	},
 	{
 		Name: "Section 4: Needy Modules",
 		Missions: []MissionInfo{
-			{Name: "A Small Wrinkle", Mission: pb.Mission_A_SMALL_WRINKLE},
-			{Name: "Pay Attention", Mission: pb.Mission_PAY_ATTENTION},
-			{Name: "The Knob", Mission: pb.Mission_THE_KNOB},
-			{Name: "Multitasker", Mission: pb.Mission_MULTI_TASKER},
+			{
+				Name:        "A Small Wrinkle",
+				Mission:     pb.Mission_A_SMALL_WRINKLE,
+				Description: "A vent gas module that needs attention while you work.",
+				Timer:       5 * time.Minute,
+				MaxStrikes:  1,
+				Faces:       1,
+				Modules: []MissionModule{
+					{Types: []string{"NEEDY_VENT_GAS"}, Count: 1},
+					{Count: 5},
+				},
+			},
+			{
+				Name:        "Pay Attention",
+				Mission:     pb.Mission_PAY_ATTENTION,
+				Description: "Keep an eye on the vent gas as well as the bomb.",
+				Timer:       5 * time.Minute,
+				MaxStrikes:  1,
+				Faces:       1,
+				Modules: []MissionModule{
+					{Count: 4},
+					{Types: []string{"NEEDY_VENT_GAS"}, Count: 1},
+				},
+			},
+			{
+				Name:        "The Knob",
+				Mission:     pb.Mission_THE_KNOB,
+				Description: "A capacitor knob that must be kept in the right position.",
+				Timer:       5 * time.Minute,
+				MaxStrikes:  1,
+				Faces:       1,
+				Modules: []MissionModule{
+					{Types: []string{"NEEDY_KNOB"}, Count: 1},
+					{Count: 5},
+				},
+			},
+			{
+				Name:        "Multitasker",
+				Mission:     pb.Mission_MULTI_TASKER,
+				Description: "Two needy modules competing for your attention.",
+				Timer:       5 * time.Minute,
+				MaxStrikes:  1,
+				Faces:       1,
+				Modules: []MissionModule{
+					{Types: []string{"NEEDY_VENT_GAS"}, Count: 1},
+					{Types: []string{"NEEDY_KNOB"}, Count: 1},
+					{Count: 4},
+				},
+			},
 		},
 	},
 	{
 		Name: "Section 5: Challenging",
 		Missions: []MissionInfo{
-			{Name: "Wires Wires Everywhere", Mission: pb.Mission_WIRES_WIRES_EVERYWHERE},
-			{Name: "Computer Hacking", Mission: pb.Mission_COMPUTER_HACKING},
-			{Name: "Who's On First Challenge", Mission: pb.Mission_WHOS_ON_FIRST_CHALLENGE},
-			{Name: "Fiendish", Mission: pb.Mission_FIENDISH},
-			{Name: "Pick Up The Pace III", Mission: pb.Mission_PICK_UP_THE_PACE_III},
-			{Name: "One With Everything", Mission: pb.Mission_ONE_WITH_EVERYTHING},
+			{
+				Name:        "Wires Wires Everywhere",
+				Mission:     pb.Mission_WIRES_WIRES_EVERYWHERE,
+				Description: "Every kind of wire module. Cut carefully.",
+				Timer:       5 * time.Minute,
+				MaxStrikes:  3,
+				Faces:       1,
+				Modules: []MissionModule{
+					{Types: []string{"WIRES"}, Count: 2},
+					{Types: []string{"COMPLICATED_WIRES"}, Count: 2},
+					{Types: []string{"WIRE_SEQUENCE"}, Count: 2},
+				},
+			},
+			{
+				Name:        "Computer Hacking",
+				Mission:     pb.Mission_COMPUTER_HACKING,
+				Description: "Five vent gas prompts guarding a handful of puzzles.",
+				Timer:       5 * time.Minute,
+				MaxStrikes:  1,
+				Faces:       2,
+				Modules: []MissionModule{
+					{Types: []string{"NEEDY_VENT_GAS"}, Count: 5},
+					{Types: []string{"PASSWORD"}, Count: 1},
+					{Types: []string{"SIMON"}, Count: 1},
+					{Types: []string{"MAZE"}, Count: 1},
+				},
+			},
+			{
+				Name:        "Who's On First Challenge",
+				Mission:     pb.Mission_WHOS_ON_FIRST_CHALLENGE,
+				Description: "Four rounds of Who's on First. Say exactly what you see.",
+				Timer:       3*time.Minute + 30*time.Second,
+				MaxStrikes:  1,
+				Faces:       1,
+				Modules: []MissionModule{
+					{Types: []string{"WHOS_ON_FIRST"}, Count: 4},
+				},
+			},
+			{
+				Name:        "Fiendish",
+				Mission:     pb.Mission_FIENDISH,
+				Description: "Five modules from the full set and a vent that won't wait.",
+				Timer:       5 * time.Minute,
+				MaxStrikes:  3,
+				Faces:       1,
+				Modules: []MissionModule{
+					{Types: []string{"NEEDY_VENT_GAS"}, Count: 1},
+					{Count: 5},
+				},
+			},
+			{
+				Name:        "Pick Up The Pace III",
+				Mission:     pb.Mission_PICK_UP_THE_PACE_III,
+				Description: "Ninety seconds and no second chances.",
+				Timer:       90 * time.Second,
+				MaxStrikes:  1,
+				Faces:       1,
+				Modules: []MissionModule{
+					{Count: 4},
+				},
+			},
+			{
+				Name:        "One With Everything",
+				Mission:     pb.Mission_ONE_WITH_EVERYTHING,
+				Description: "One of every module across two faces.",
+				Timer:       6 * time.Minute,
+				MaxStrikes:  3,
+				Faces:       2,
+				Modules: []MissionModule{
+					{Types: []string{"WIRES"}, Count: 1},
+					{Types: []string{"BIG_BUTTON"}, Count: 1},
+					{Types: []string{"KEYPAD"}, Count: 1},
+					{Types: []string{"SIMON"}, Count: 1},
+					{Types: []string{"WHOS_ON_FIRST"}, Count: 1},
+					{Types: []string{"MEMORY"}, Count: 1},
+					{Types: []string{"MORSE"}, Count: 1},
+					{Types: []string{"COMPLICATED_WIRES"}, Count: 1},
+					{Types: []string{"WIRE_SEQUENCE"}, Count: 1},
+					{Types: []string{"MAZE"}, Count: 1},
+					{Types: []string{"PASSWORD"}, Count: 1},
+				},
+			},
 		},
 	},
 	{
 		Name: "Section 6: Extreme",
 		Missions: []MissionInfo{
-			{Name: "Pick Up The Pace IV", Mission: pb.Mission_PICK_UP_THE_PACE_IV},
-			{Name: "Juggler", Mission: pb.Mission_JUGGLER},
-			{Name: "Double Trouble", Mission: pb.Mission_DOUBLE_TROUBLE},
-			{Name: "I Am Hardcore", Mission: pb.Mission_I_AM_HARDCORE},
+			{
+				Name:        "Pick Up The Pace IV",
+				Mission:     pb.Mission_PICK_UP_THE_PACE_IV,
+				Description: "Eighty seconds, four modules, one strike.",
+				Timer:       80 * time.Second,
+				MaxStrikes:  1,
+				Faces:       1,
+				Modules: []MissionModule{
+					{Types: []string{"KEYPAD"}, Count: 1},
+					{Types: []string{"COMPLICATED_WIRES"}, Count: 1},
+					{Types: []string{"WIRE_SEQUENCE"}, Count: 1},
+					{Types: []string{"BIG_BUTTON", "WIRES"}, Count: 1},
+				},
+			},
+			{
+				Name:        "Juggler",
+				Mission:     pb.Mission_JUGGLER,
+				Description: "Two needy modules and a bomb full of distractions.",
+				Timer:       5 * time.Minute,
+				MaxStrikes:  1,
+				Faces:       2,
+				Modules: []MissionModule{
+					{Types: []string{"NEEDY_KNOB"}, Count: 1},
+					{Types: []string{"SIMON"}, Count: 1},
+					{Types: []string{"WIRES"}, Count: 1},
+					{Types: []string{"MORSE"}, Count: 1},
+					{Types: []string{"NEEDY_VENT_GAS"}, Count: 1},
+					{Count: 3},
+				},
+			},
+			{
+				Name:        "Double Trouble",
+				Mission:     pb.Mission_DOUBLE_TROUBLE,
+				Description: "Two knobs to keep in line while you defuse six modules.",
+				Timer:       5 * time.Minute,
+				MaxStrikes:  1,
+				Faces:       2,
+				Modules: []MissionModule{
+					{Types: []string{"NEEDY_KNOB"}, Count: 2},
+					{Count: 6},
+				},
+			},
+			{
+				Name:        "I Am Hardcore",
+				Mission:     pb.Mission_I_AM_HARDCORE,
+				Description: "Ten modules, a needy module and a single strike.",
+				Timer:       5 * time.Minute,
+				MaxStrikes:  1,
+				Faces:       2,
+				Modules: []MissionModule{
+					{Types: []string{"NEEDY_KNOB", "NEEDY_VENT_GAS"}, Count: 1},
+					{Count: 10},
+				},
+			},
 		},
 	},
 	{
 		Name: "Section 7: Exotic",
 		Missions: []MissionInfo{
-			{Name: "Blinkenlights", Mission: pb.Mission_BLINKENLIGHTS},
-			{Name: "Applied Theory", Mission: pb.Mission_APPLIED_THEORY},
-			{Name: "A Maze Ing", Mission: pb.Mission_A_MAZE_ING},
-			{Name: "Snip Snap", Mission: pb.Mission_SNIP_SNAP},
-			{Name: "Rainbow Table", Mission: pb.Mission_RAINBOW_TABLE},
-			{Name: "Blinkenlights II", Mission: pb.Mission_BLINKENLIGHTS_II},
+			{
+				Name:        "Blinkenlights",
+				Mission:     pb.Mission_BLINKENLIGHTS,
+				Description: "Five Simon Says modules, flashing away.",
+				Timer:       90 * time.Second,
+				MaxStrikes:  1,
+				Faces:       1,
+				Modules: []MissionModule{
+					{Types: []string{"SIMON"}, Count: 5},
+				},
+			},
+			{
+				Name:        "Applied Theory",
+				Mission:     pb.Mission_APPLIED_THEORY,
+				Description: "Nothing but complicated wires.",
+				Timer:       3 * time.Minute,
+				MaxStrikes:  1,
+				Faces:       2,
+				Modules: []MissionModule{
+					{Types: []string{"COMPLICATED_WIRES"}, Count: 11},
+				},
+			},
+			{
+				Name:        "A Maze Ing",
+				Mission:     pb.Mission_A_MAZE_ING,
+				Description: "Eight mazes. Know your left from your right.",
+				Timer:       3 * time.Minute,
+				MaxStrikes:  1,
+				Faces:       2,
+				Modules: []MissionModule{
+					{Types: []string{"MAZE"}, Count: 8},
+				},
+			},
+			{
+				Name:        "Snip Snap",
+				Mission:     pb.Mission_SNIP_SNAP,
+				Description: "Six wire sequences in a row.",
+				Timer:       3 * time.Minute,
+				MaxStrikes:  1,
+				Faces:       1,
+				Modules: []MissionModule{
+					{Types: []string{"WIRE_SEQUENCE"}, Count: 6},
+				},
+			},
+			{
+				Name:        "Rainbow Table",
+				Mission:     pb.Mission_RAINBOW_TABLE,
+				Description: "Nine passwords to crack.",
+				Timer:       4 * time.Minute,
+				MaxStrikes:  1,
+				Faces:       2,
+				Modules: []MissionModule{
+					{Types: []string{"PASSWORD"}, Count: 9},
+				},
+			},
+			{
+				Name:        "Blinkenlights II",
+				Mission:     pb.Mission_BLINKENLIGHTS_II,
+				Description: "Flashing lights and beeping signals.",
+				Timer:       3 * time.Minute,
+				MaxStrikes:  1,
+				Faces:       1,
+				Modules: []MissionModule{
+					{Types: []string{"SIMON"}, Count: 3},
+					{Types: []string{"MORSE"}, Count: 3},
+				},
+			},
 		},
 	},
 }
 
+// campaignMode reports whether sections unlock one at a time. It needs a
+// profile to remember progress in.
+func (m *Model) campaignMode() bool {
+	return m.profile != nil && m.profile.Prefs.Campaign
+}
+
+func (m *Model) missionResult(mission MissionInfo) profile.MissionResult {
+	if m.profile == nil {
+		return profile.MissionResult{}
+	}
+	return m.profile.Missions[mission.key()]
+}
+
+func (m *Model) sectionProgress(section MissionSection) (completed, total int) {
+	for _, mission := range section.Missions {
+		if m.missionResult(mission).Completed {
+			completed++
+		}
+	}
+	return completed, len(section.Missions)
+}
+
+// sectionUnlocked reports whether section i can be played. In campaign mode
+// every mission in the previous section must be defused first.
+func (m *Model) sectionUnlocked(i int) bool {
+	if !m.campaignMode() || i == 0 {
+		return true
+	}
+	completed, total := m.sectionProgress(m.missionSections[i-1])
+	return completed == total
+}
+
 func (m *Model) sectionSelectView() string {
 	var items []string
-	for i, section := range missionSections {
-		if i == m.sectionSelection {
-			items = append(items, styles.Active.Render("> "+section.Name))
-		} else {
-			items = append(items, "  "+section.Name)
+	for i, section := range m.missionSections {
+		status, mark := "", " "
+		if !m.sectionUnlocked(i) {
+			status = "LOCKED"
+		} else if m.profile != nil {
+			completed, total := m.sectionProgress(section)
+			status = fmt.Sprintf("%d/%d", completed, total)
+			if completed == total {
+				mark = "✓"
+			}
+		}
+		line := fmt.Sprintf("%-28s %6s %s", section.Name, status, mark)
+
+		switch {
+		case i == m.sectionSelection:
+			items = append(items, styles.Active.Render("> "+line))
+		case !m.sectionUnlocked(i):
+			items = append(items, styles.Help.Render("  "+line))
+		default:
+			items = append(items, "  "+line)
 		}
 	}
 
-	content := lipgloss.JoinVertical(
-		lipgloss.Center,
+	rows := []string{
 		styles.Title.Render("SELECT SECTION"),
 		"",
 		lipgloss.JoinVertical(lipgloss.Left, items...),
-	)
+	}
+	if m.profile != nil {
+		mode := "OFF"
+		if m.campaignMode() {
+			mode = "ON"
+		}
+		rows = append(rows, "", styles.Subtitle.Render("Campaign mode: "+mode))
+	}
 
 	return lipgloss.JoinVertical(
 		lipgloss.Top,
-		styles.HeaderBox.Render(styles.Title.Render("DEFUSE.PARTY")),
-		styles.ContentBox.Render(content),
+		m.layout().Header().Render(styles.Title.Render("DEFUSE.PARTY")),
+		m.layout().Content().Render(lipgloss.JoinVertical(lipgloss.Center, rows...)),
 		m.renderFooter(),
 	)
 }
 
 func (m *Model) missionSelectView() string {
-	section := missionSections[m.sectionSelection]
+	section := m.missionSections[m.sectionSelection]
 	var items []string
 	for i, mission := range section.Missions {
+		result := m.missionResult(mission)
+		mark, best := " ", ""
+		if result.Completed {
+			mark = "✓"
+			best = fmt.Sprintf("%5s  %d✕", formatTimer(result.BestTime), result.FewestStrikes)
+		}
+		line := fmt.Sprintf("%s %-30s %9s", mark, mission.Name, best)
+
 		if i == m.missionSelection {
-			items = append(items, styles.Active.Render("> "+mission.Name))
+			items = append(items, styles.Active.Render("> "+line))
 		} else {
-			items = append(items, "  "+mission.Name)
+			items = append(items, "  "+line)
 		}
 	}
 
@@ -132,8 +596,8 @@ func (m *Model) missionSelectView() string {
 
 	return lipgloss.JoinVertical(
 		lipgloss.Top,
-		styles.HeaderBox.Render(styles.Title.Render("DEFUSE.PARTY")),
-		styles.ContentBox.Render(content),
+		m.layout().Header().Render(styles.Title.Render("DEFUSE.PARTY")),
+		m.layout().Content().Render(content),
 		m.renderFooter(),
 	)
 }
@@ -146,12 +610,21 @@ func (m *Model) handleSectionSelectKeys(key string) (tea.Cmd, bool) {
 			m.sectionSelection--
 		}
 	case "down", "j":
-		if m.sectionSelection < len(missionSections)-1 {
+		if m.sectionSelection < len(m.missionSections)-1 {
 			m.sectionSelection++
 		}
 	case "enter":
+		if !m.sectionUnlocked(m.sectionSelection) {
+			previous := m.missionSections[m.sectionSelection-1].Name
+			return m.notify(toastWarning, "Defuse every mission in "+previous+" to unlock this section"), true
+		}
 		m.state = StateMissionSelect
 		m.missionSelection = 0
+	case "c", "C":
+		if m.profile == nil {
+			return nil, false
+		}
+		return m.setCampaign(!m.campaignMode()), true
 	case "esc":
 		m.state = StateMainMenu
 		m.menuSelection = 0
@@ -162,7 +635,7 @@ func (m *Model) handleSectionSelectKeys(key string) (tea.Cmd, bool) {
 }
 
 func (m *Model) handleMissionSelectKeys(key string) (tea.Cmd, bool) {
-	section := missionSections[m.sectionSelection]
+	section := m.missionSections[m.sectionSelection]
 	handled := true
 	switch key {
 	case "up", "k":
@@ -174,16 +647,8 @@ func (m *Model) handleMissionSelectKeys(key string) (tea.Cmd, bool) {
 			m.missionSelection++
 		}
 	case "enter":
-		mission := section.Missions[m.missionSelection]
-		m.pendingGameConfig = &pb.GameConfig{
-			ConfigType: &pb.GameConfig_Preset{
-				Preset: &pb.PresetMissionConfig{
-					Mission: mission.Mission,
-				},
-			},
-		}
-		m.state = StateLoading
-		return m.StartGame(m.pendingGameConfig), true
+		m.state = StateMissionBriefing
+		m.briefingScroll = 0
 	case "esc":
 		m.state = StateSectionSelect
 		m.missionSelection = 0
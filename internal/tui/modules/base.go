@@ -1,17 +1,51 @@
 package modules
 
 import (
+	"context"
+	"time"
+
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/ZaneH/defuse.party-tui/internal/client"
 	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
 )
 
+// ModuleResultMsg carries the outcome of a player input. It is delivered to
+// the top-level model, which forwards it to the owning module's Update so
+// that module state is only ever changed on the UI goroutine.
+type ModuleResultMsg struct {
+	ModuleID string
+	Input    *pb.PlayerInput
+	Result   *pb.PlayerInputResult
+	Err      error
+}
+
+type BackToBombMsg struct{}
+
+// maxViewWidth is the width modules lay their view out in when they have the
+// room for it.
+const maxViewWidth = 60
+
+// viewWidth is the width to lay a module's view out in, given the width from
+// its last tea.WindowSizeMsg. Zero means none has arrived yet.
+func viewWidth(width int) int {
+	if width <= 0 {
+		return maxViewWidth
+	}
+	return min(width, maxViewWidth)
+}
+
 type ModuleModel interface {
 	Init() tea.Cmd
 	Update(msg tea.Msg) (tea.Model, tea.Cmd)
 	View() string
 
+	// OnEnter is called every time the module becomes the active module,
+	// including the first time after Init. OnLeave is called when the player
+	// navigates away from it or the game ends.
+	OnEnter() tea.Cmd
+	OnLeave()
+
 	ID() string
 	ModuleType() pb.Module_ModuleType
 	IsSolved() bool
@@ -20,6 +54,28 @@ type ModuleModel interface {
 	Footer() string
 }
 
+// noLifecycle provides OnEnter and OnLeave for modules that have nothing to
+// start or stop as the player comes and goes.
+type noLifecycle struct{}
+
+func (noLifecycle) OnEnter() tea.Cmd { return nil }
+
+func (noLifecycle) OnLeave() {}
+
+// NeedyModule is implemented by modules with a countdown that has to be
+// serviced periodically. Remaining reports false while the countdown is idle.
+type NeedyModule interface {
+	ModuleModel
+	Remaining() (time.Duration, bool)
+}
+
+// TickMsg is implemented by messages that drive a single module's timers.
+// They are routed to their module whether or not it is the active one, so
+// background modules (e.g. needy countdowns) keep running.
+type TickMsg interface {
+	TargetModuleID() string
+}
+
 func NewModule(mod *pb.Module, client client.GameClient, sessionID, bombID string) ModuleModel {
 	switch mod.GetType() {
 	case pb.Module_CLOCK:
@@ -50,3 +106,24 @@ func NewModule(mod *pb.Module, client client.GameClient, sessionID, bombID strin
 		return NewUnimplementedModule(mod)
 	}
 }
+
+// sendInput returns a command that only performs the RPC. Commands run on
+// their own goroutine, so they must not touch module state; all handling
+// happens when the resulting ModuleResultMsg reaches Update.
+func sendInput(client client.GameClient, input *pb.PlayerInput) tea.Cmd {
+	return func() tea.Msg {
+		result, err := client.SendInput(context.Background(), input)
+		return ModuleResultMsg{
+			ModuleID: input.GetModuleId(),
+			Input:    input,
+			Result:   result,
+			Err:      err,
+		}
+	}
+}
+
+func inputError(moduleID string, err error) tea.Cmd {
+	return func() tea.Msg {
+		return ModuleResultMsg{ModuleID: moduleID, Err: err}
+	}
+}
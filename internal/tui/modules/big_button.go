@@ -1,7 +1,6 @@
 package modules
 
 import (
-	"context"
 	"fmt"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,6 +12,8 @@ import (
 )
 
 type BigButtonModule struct {
+	noLifecycle
+
 	mod       *pb.Module
 	client    client.GameClient
 	sessionID string
@@ -66,6 +67,8 @@ func (m *BigButtonModule) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return BackToBombMsg{}
 			}
 		}
+	case ModuleResultMsg:
+		m.handleResult(msg)
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -73,138 +76,95 @@ func (m *BigButtonModule) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-func (m *BigButtonModule) sendTap() tea.Cmd {
-	return func() tea.Msg {
-		state := m.mod.GetBigButtonState()
-		if state == nil {
-			return ModuleResultMsg{Err: fmt.Errorf("no button state")}
-		}
-
-		input := &pb.PlayerInput{
-			SessionId: m.sessionID,
-			BombId:    m.bombID,
-			ModuleId:  m.mod.GetId(),
-			Input: &pb.PlayerInput_BigButtonInput{
-				BigButtonInput: &pb.BigButtonInput{
-					PressType: pb.PressType_TAP,
-				},
+func (m *BigButtonModule) press(pressType pb.PressType) tea.Cmd {
+	return sendInput(m.client, &pb.PlayerInput{
+		SessionId: m.sessionID,
+		BombId:    m.bombID,
+		ModuleId:  m.mod.GetId(),
+		Input: &pb.PlayerInput_BigButtonInput{
+			BigButtonInput: &pb.BigButtonInput{
+				PressType: pressType,
 			},
-		}
-
-		result, err := m.client.SendInput(context.Background(), input)
-		if err != nil {
-			return ModuleResultMsg{Err: err}
-		}
-
-		if result.GetStrike() {
-			m.message = "STRIKE!"
-			m.messageType = "error"
-		} else if result.GetSolved() {
-			m.message = "Module solved!"
-			m.messageType = "success"
-		} else {
-			m.message = ""
-			m.messageType = ""
-		}
+		},
+	})
+}
 
-		return ModuleResultMsg{Result: result}
+func (m *BigButtonModule) sendTap() tea.Cmd {
+	if m.mod.GetBigButtonState() == nil {
+		return inputError(m.mod.GetId(), fmt.Errorf("no button state"))
 	}
+	return m.press(pb.PressType_TAP)
 }
 
 func (m *BigButtonModule) sendHold() tea.Cmd {
-	return func() tea.Msg {
-		state := m.mod.GetBigButtonState()
-		if state == nil {
-			return ModuleResultMsg{Err: fmt.Errorf("no button state")}
-		}
-
-		m.isHolding = true
-		m.holdSent = true
-
-		input := &pb.PlayerInput{
-			SessionId: m.sessionID,
-			BombId:    m.bombID,
-			ModuleId:  m.mod.GetId(),
-			Input: &pb.PlayerInput_BigButtonInput{
-				BigButtonInput: &pb.BigButtonInput{
-					PressType: pb.PressType_HOLD,
-				},
-			},
-		}
+	if m.mod.GetBigButtonState() == nil {
+		return inputError(m.mod.GetId(), fmt.Errorf("no button state"))
+	}
 
-		result, err := m.client.SendInput(context.Background(), input)
-		if err != nil {
-			m.isHolding = false
-			m.holdSent = false
-			return ModuleResultMsg{Err: err}
-		}
+	m.isHolding = true
+	m.holdSent = true
+	return m.press(pb.PressType_HOLD)
+}
 
-		if stripResult := result.GetBigButtonInputResult(); stripResult != nil {
-			m.stripColor = stripResult.GetStripColor()
-		}
+func (m *BigButtonModule) sendRelease() tea.Cmd {
+	if !m.isHolding {
+		return inputError(m.mod.GetId(), fmt.Errorf("not holding"))
+	}
 
-		if result.GetStrike() {
-			m.isHolding = false
-			m.holdSent = false
-			m.message = "STRIKE!"
-			m.messageType = "error"
-			m.stripColor = pb.Color_UNKNOWN
-		}
+	m.isHolding = false
+	m.holdSent = false
 
-		return ModuleResultMsg{Result: result}
+	if m.mod.GetBigButtonState() == nil {
+		m.stripColor = pb.Color_UNKNOWN
+		return inputError(m.mod.GetId(), fmt.Errorf("no button state"))
 	}
+
+	return m.press(pb.PressType_RELEASE)
 }
 
-func (m *BigButtonModule) sendRelease() tea.Cmd {
-	return func() tea.Msg {
-		if !m.isHolding {
-			return ModuleResultMsg{Err: fmt.Errorf("not holding")}
-		}
+func (m *BigButtonModule) handleResult(msg ModuleResultMsg) {
+	pressType := msg.Input.GetBigButtonInput().GetPressType()
 
-		state := m.mod.GetBigButtonState()
-		if state == nil {
+	if msg.Err != nil {
+		switch pressType {
+		case pb.PressType_HOLD:
 			m.isHolding = false
 			m.holdSent = false
+		case pb.PressType_RELEASE:
 			m.stripColor = pb.Color_UNKNOWN
-			return ModuleResultMsg{Err: fmt.Errorf("no button state")}
-		}
-
-		m.isHolding = false
-		m.holdSent = false
-		releaseTime := int64(0)
-
-		input := &pb.PlayerInput{
-			SessionId: m.sessionID,
-			BombId:    m.bombID,
-			ModuleId:  m.mod.GetId(),
-			Input: &pb.PlayerInput_BigButtonInput{
-				BigButtonInput: &pb.BigButtonInput{
-					PressType:        pb.PressType_RELEASE,
-					ReleaseTimestamp: releaseTime,
-				},
-			},
 		}
+		return
+	}
 
-		result, err := m.client.SendInput(context.Background(), input)
-		if err != nil {
-			m.stripColor = pb.Color_UNKNOWN
-			return ModuleResultMsg{Err: err}
-		}
-
-		m.stripColor = pb.Color_UNKNOWN
-
+	result := msg.Result
+	switch pressType {
+	case pb.PressType_HOLD:
 		if result.GetStrike() {
+			m.isHolding = false
+			m.holdSent = false
 			m.message = "STRIKE!"
 			m.messageType = "error"
-		} else if result.GetSolved() {
-			m.message = "Module solved!"
-			m.messageType = "success"
-		} else {
-			m.message = ""
-			m.messageType = ""
+			m.stripColor = pb.Color_UNKNOWN
+			return
+		}
+		// The button may have been released before the hold was acknowledged.
+		if stripResult := result.GetBigButtonInputResult(); stripResult != nil && m.isHolding {
+			m.stripColor = stripResult.GetStripColor()
 		}
+		return
+	case pb.PressType_RELEASE:
+		m.stripColor = pb.Color_UNKNOWN
+	}
 
-		return ModuleResultMsg{Result: result}
+	if result.GetStrike() {
+		m.message = "STRIKE!"
+		m.messageType = "error"
+	} else if result.GetSolved() {
+		m.message = "Module solved!"
+		m.messageType = "success"
+	} else {
+		m.message = ""
+		m.messageType = ""
 	}
 }
 
@@ -276,7 +236,7 @@ func (m *BigButtonModule) View() string {
 	}
 
 	return lipgloss.NewStyle().
-		Width(60).
+		Width(viewWidth(m.width)).
 		Align(lipgloss.Center).
 		Render(content)
 }
@@ -339,9 +299,9 @@ func buttonColorToStyle(c pb.Color) lipgloss.Style {
 	case pb.Color_YELLOW:
 		return styles.Yellow
 	case pb.Color_ORANGE:
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("208"))
+		return styles.Orange
 	case pb.Color_PINK:
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("219"))
+		return styles.Pink
 	case pb.Color_GREEN:
 		return styles.Green
 	default:
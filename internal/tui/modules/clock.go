@@ -13,11 +13,15 @@ import (
 )
 
 type ClockModule struct {
+	noLifecycle
+
 	mod        *pb.Module
+	now        func() time.Time
 	startedAt  time.Time
 	duration   time.Duration
 	strikes    int32
 	maxStrikes int32
+	ascii      bool
 	width      int
 	height     int
 }
@@ -114,13 +118,32 @@ var segmentDigits = map[rune][]string{
 	},
 }
 
-func NewClockModule(mod *pb.Module, startedAt time.Time, duration time.Duration, strikes, maxStrikes int32) *ClockModule {
+// asciiSegmentDigits are segmentDigits for terminals that can't draw block or
+// box-drawing characters: the blocks become hashes and the shadows go.
+var asciiSegmentDigits = func() map[rune][]string {
+	shadowless := strings.NewReplacer("█", "#", "╔", " ", "╗", " ", "╚", " ", "╝", " ", "═", " ", "║", " ")
+	digits := make(map[rune][]string, len(segmentDigits))
+	for r, pattern := range segmentDigits {
+		for _, line := range pattern {
+			digits[r] = append(digits[r], shadowless.Replace(line))
+		}
+	}
+	// The colon is nothing but shadow, so it needs its own dots.
+	digits[':'] = []string{"   ", " ##", "   ", " ##", "   ", "   "}
+	return digits
+}()
+
+// NewClockModule creates the clock, counting down by the game's clock now. If
+// ascii is set it draws the timer without block characters.
+func NewClockModule(mod *pb.Module, now func() time.Time, startedAt time.Time, duration time.Duration, strikes, maxStrikes int32, ascii bool) *ClockModule {
 	return &ClockModule{
 		mod:        mod,
+		now:        now,
 		startedAt:  startedAt,
 		duration:   duration,
 		strikes:    strikes,
 		maxStrikes: maxStrikes,
+		ascii:      ascii,
 	}
 }
 
@@ -144,7 +167,7 @@ func (m *ClockModule) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *ClockModule) View() string {
-	elapsed := time.Since(m.startedAt)
+	elapsed := m.now().Sub(m.startedAt)
 	remaining := m.duration - elapsed
 	if remaining < 0 {
 		remaining = 0
@@ -190,16 +213,21 @@ func (m *ClockModule) View() string {
 	)
 
 	return lipgloss.NewStyle().
-		Width(60).
+		Width(viewWidth(m.width)).
 		Align(lipgloss.Center).
 		Render(content)
 }
 
 func (m *ClockModule) renderLargeTimer(text string) string {
+	font := segmentDigits
+	if m.ascii {
+		font = asciiSegmentDigits
+	}
+
 	// Get digit patterns for each character
 	var digitLines [][]string
 	for _, char := range text {
-		if pattern, exists := segmentDigits[char]; exists {
+		if pattern, exists := font[char]; exists {
 			digitLines = append(digitLines, pattern)
 		}
 	}
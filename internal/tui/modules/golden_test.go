@@ -0,0 +1,248 @@
+package modules
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ZaneH/defuse.party-tui/internal/client/clienttest"
+	"github.com/ZaneH/defuse.party-tui/internal/tui/tuitest"
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+type goldenStep struct {
+	label   string
+	results []*pb.PlayerInputResult
+	err     error
+	keys    []string
+}
+
+type goldenCase struct {
+	mod    *pb.Module
+	steps  []goldenStep
+	inputs int
+}
+
+func result(moduleID string, fn func(*pb.PlayerInputResult)) *pb.PlayerInputResult {
+	r := &pb.PlayerInputResult{ModuleId: moduleID}
+	if fn != nil {
+		fn(r)
+	}
+	return r
+}
+
+func strike(r *pb.PlayerInputResult) { r.Strike = true }
+func solve(r *pb.PlayerInputResult)  { r.Solved = true }
+
+func goldenCases() map[string]goldenCase {
+	return map[string]goldenCase{
+		"wires": {
+			mod: &pb.Module{Id: "wires", Type: pb.Module_WIRES, State: &pb.Module_WiresState{WiresState: &pb.WiresState{Wires: []*pb.Wire{
+				{WireColor: pb.Color_RED, Position: 1},
+				{WireColor: pb.Color_BLUE, Position: 2},
+				{WireColor: pb.Color_WHITE, Position: 3},
+				{WireColor: pb.Color_BLACK, Position: 4},
+			}}}},
+			steps: []goldenStep{
+				{label: "cut wire 2 (strike)", keys: []string{"2"}, results: []*pb.PlayerInputResult{result("wires", strike)}},
+				{label: "cut wire 4 (solve)", keys: []string{"4"}, results: []*pb.PlayerInputResult{result("wires", solve)}},
+			},
+			inputs: 2,
+		},
+		"big_button": {
+			mod: &pb.Module{Id: "button", Type: pb.Module_BIG_BUTTON, State: &pb.Module_BigButtonState{BigButtonState: &pb.BigButtonState{
+				ButtonColor: pb.Color_BLUE,
+				Label:       "ABORT",
+			}}},
+			steps: []goldenStep{
+				{label: "hold", keys: []string{"h"}, results: []*pb.PlayerInputResult{result("button", func(r *pb.PlayerInputResult) {
+					r.Result = &pb.PlayerInputResult_BigButtonInputResult{BigButtonInputResult: &pb.BigButtonInputResult{StripColor: pb.Color_YELLOW}}
+				})}},
+				{label: "release (solve)", keys: []string{"r"}, results: []*pb.PlayerInputResult{result("button", solve)}},
+			},
+			inputs: 2,
+		},
+		"keypad": {
+			mod: &pb.Module{Id: "keypad", Type: pb.Module_KEYPAD, State: &pb.Module_KeypadState{KeypadState: &pb.KeypadState{DisplayedSymbols: []pb.Symbol{
+				pb.Symbol_COPYRIGHT, pb.Symbol_FILLEDSTAR, pb.Symbol_HOLLOWSTAR, pb.Symbol_SMILEYFACE,
+			}}}},
+			steps: []goldenStep{
+				{label: "press 1", keys: []string{"1"}, results: []*pb.PlayerInputResult{result("keypad", nil)}},
+				{label: "press 1 again (ignored)", keys: []string{"1"}},
+				{label: "press 3 (strike)", keys: []string{"3"}, results: []*pb.PlayerInputResult{result("keypad", strike)}},
+			},
+			inputs: 2,
+		},
+		"password": {
+			mod: &pb.Module{Id: "password", Type: pb.Module_PASSWORD, State: &pb.Module_PasswordState{PasswordState: &pb.PasswordState{Letters: "ABOUT"}}},
+			steps: []goldenStep{
+				{label: "column 2 up", keys: []string{"2", "up"}, results: []*pb.PlayerInputResult{result("password", func(r *pb.PlayerInputResult) {
+					r.Result = &pb.PlayerInputResult_PasswordInputResult{PasswordInputResult: &pb.PasswordInputResult{PasswordState: &pb.PasswordState{Letters: "ACOUT"}}}
+				})}},
+				{label: "submit (strike)", keys: []string{"enter"}, results: []*pb.PlayerInputResult{result("password", strike)}},
+			},
+			inputs: 2,
+		},
+		"morse": {
+			mod: &pb.Module{Id: "morse", Type: pb.Module_MORSE, State: &pb.Module_MorseState{MorseState: &pb.MorseState{
+				DisplayedPattern:   "... .... . .-.. .-..",
+				DisplayedFrequency: 3.505,
+			}}},
+			steps: []goldenStep{
+				{label: "frequency up", keys: []string{"right"}, results: []*pb.PlayerInputResult{result("morse", func(r *pb.PlayerInputResult) {
+					r.Result = &pb.PlayerInputResult_MorseInputResult{MorseInputResult: &pb.MorseInputResult{MorseState: &pb.MorseState{
+						DisplayedPattern:       "... .... . .-.. .-..",
+						DisplayedFrequency:     3.515,
+						SelectedFrequencyIndex: 1,
+					}}}
+				})}},
+				{label: "transmit (solve)", keys: []string{"enter"}, results: []*pb.PlayerInputResult{result("morse", solve)}},
+			},
+			inputs: 2,
+		},
+		"simon": {
+			mod: &pb.Module{Id: "simon", Type: pb.Module_SIMON, State: &pb.Module_SimonState{SimonState: &pb.SimonState{
+				CurrentSequence: []pb.Color{pb.Color_RED},
+			}}},
+			steps: []goldenStep{
+				{label: "press red (next stage)", keys: []string{"r"}, results: []*pb.PlayerInputResult{result("simon", func(r *pb.PlayerInputResult) {
+					r.Result = &pb.PlayerInputResult_SimonInputResult{SimonInputResult: &pb.SimonInputResult{
+						HasFinishedSeq:  true,
+						DisplaySequence: []pb.Color{pb.Color_RED, pb.Color_BLUE},
+					}}
+				})}},
+				{label: "press green (strike)", keys: []string{"g"}, results: []*pb.PlayerInputResult{result("simon", strike)}},
+			},
+			inputs: 2,
+		},
+		"memory": {
+			mod: &pb.Module{Id: "memory", Type: pb.Module_MEMORY, State: &pb.Module_MemoryState{MemoryState: &pb.MemoryState{
+				ScreenNumber:     2,
+				DisplayedNumbers: []int32{3, 1, 4, 2},
+				Stage:            1,
+			}}},
+			steps: []goldenStep{
+				{label: "press 2 (next stage)", keys: []string{"2"}, results: []*pb.PlayerInputResult{result("memory", func(r *pb.PlayerInputResult) {
+					r.Result = &pb.PlayerInputResult_MemoryInputResult{MemoryInputResult: &pb.MemoryInputResult{MemoryState: &pb.MemoryState{
+						ScreenNumber:     4,
+						DisplayedNumbers: []int32{2, 4, 1, 3},
+						Stage:            2,
+					}}}
+				})}},
+			},
+			inputs: 1,
+		},
+		"whos_on_first": {
+			mod: &pb.Module{Id: "whos", Type: pb.Module_WHOS_ON_FIRST, State: &pb.Module_WhosOnFirstState{WhosOnFirstState: &pb.WhosOnFirstState{
+				ScreenWord:  "BLANK",
+				ButtonWords: []string{"READY", "FIRST", "NO", "BLANK", "NOTHING", "YES"},
+				Stage:       1,
+			}}},
+			steps: []goldenStep{
+				{label: "press 4 (strike)", keys: []string{"4"}, results: []*pb.PlayerInputResult{result("whos", strike)}},
+			},
+			inputs: 1,
+		},
+		"maze": {
+			mod: &pb.Module{Id: "maze", Type: pb.Module_MAZE, State: &pb.Module_MazeState{MazeState: &pb.MazeState{
+				Marker_1:       &pb.Point2D{X: 0, Y: 1},
+				Marker_2:       &pb.Point2D{X: 5, Y: 2},
+				PlayerPosition: &pb.Point2D{X: 2, Y: 2},
+				GoalPosition:   &pb.Point2D{X: 4, Y: 4},
+			}}},
+			steps: []goldenStep{
+				{label: "move east", keys: []string{"right"}, results: []*pb.PlayerInputResult{result("maze", func(r *pb.PlayerInputResult) {
+					r.Result = &pb.PlayerInputResult_MazeInputResult{MazeInputResult: &pb.MazeInputResult{MazeState: &pb.MazeState{
+						Marker_1:       &pb.Point2D{X: 0, Y: 1},
+						Marker_2:       &pb.Point2D{X: 5, Y: 2},
+						PlayerPosition: &pb.Point2D{X: 3, Y: 2},
+						GoalPosition:   &pb.Point2D{X: 4, Y: 4},
+					}}}
+				})}},
+				{label: "move north (strike)", keys: []string{"up"}, results: []*pb.PlayerInputResult{result("maze", strike)}},
+			},
+			inputs: 2,
+		},
+		"needy_vent_gas": {
+			mod: &pb.Module{Id: "vent", Type: pb.Module_NEEDY_VENT_GAS, State: &pb.Module_NeedyVentGasState{NeedyVentGasState: &pb.NeedyVentGasState{
+				DisplayedQuestion: "VENT GAS?",
+			}}},
+			steps: []goldenStep{
+				{label: "answer no (strike)", keys: []string{"n"}, results: []*pb.PlayerInputResult{result("vent", strike)}},
+			},
+			inputs: 1,
+		},
+		"needy_knob": {
+			mod: &pb.Module{Id: "knob", Type: pb.Module_NEEDY_KNOB, State: &pb.Module_NeedyKnobState{NeedyKnobState: &pb.NeedyKnobState{
+				DisplayedPatternFirstRow:  []bool{true, false, true, false, true, false},
+				DisplayedPatternSecondRow: []bool{false, true, false, true, false, true},
+				DialDirection:             pb.CardinalDirection_NORTH,
+			}}},
+			steps: []goldenStep{
+				{label: "rotate", keys: []string{"enter"}, results: []*pb.PlayerInputResult{result("knob", func(r *pb.PlayerInputResult) {
+					r.Result = &pb.PlayerInputResult_NeedyKnobInputResult{NeedyKnobInputResult: &pb.NeedyKnobInputResult{NeedyKnobState: &pb.NeedyKnobState{
+						DisplayedPatternFirstRow:  []bool{true, false, true, false, true, false},
+						DisplayedPatternSecondRow: []bool{false, true, false, true, false, true},
+						DialDirection:             pb.CardinalDirection_EAST,
+					}}}
+				})}},
+			},
+			inputs: 1,
+		},
+		"rpc_error": {
+			mod: &pb.Module{Id: "wires", Type: pb.Module_WIRES, State: &pb.Module_WiresState{WiresState: &pb.WiresState{Wires: []*pb.Wire{
+				{WireColor: pb.Color_YELLOW, Position: 1},
+				{WireColor: pb.Color_RED, Position: 2},
+			}}}},
+			steps: []goldenStep{
+				{label: "cut wire 1 (rpc fails)", keys: []string{"1"}, err: errors.New("unavailable")},
+			},
+			inputs: 1,
+		},
+	}
+}
+
+func TestModuleGolden(t *testing.T) {
+	for name, tc := range goldenCases() {
+		t.Run(name, func(t *testing.T) {
+			c := clienttest.New()
+			d := tuitest.NewDriver(t, NewModule(tc.mod, c, "session", "bomb-1"))
+			d.Snapshot("initial")
+
+			for _, step := range tc.steps {
+				c.QueueResult(step.results...)
+				if step.err != nil {
+					c.QueueError(step.err)
+				}
+				d.Type(step.keys...).Snapshot(step.label)
+			}
+
+			d.RequireGolden()
+
+			if got := len(c.Inputs()); got != tc.inputs {
+				t.Errorf("sent %d inputs, want %d", got, tc.inputs)
+			}
+			for _, input := range c.Inputs() {
+				if input.GetModuleId() != tc.mod.GetId() || input.GetBombId() != "bomb-1" || input.GetSessionId() != "session" {
+					t.Errorf("input addressed to %s/%s/%s", input.GetSessionId(), input.GetBombId(), input.GetModuleId())
+				}
+			}
+		})
+	}
+}
+
+func TestClockGolden(t *testing.T) {
+	mod := &pb.Module{Id: "clock", Type: pb.Module_CLOCK}
+	start := time.Now()
+	clock := NewClockModule(mod, func() time.Time { return start }, start, 5*time.Minute, 1, 3, false)
+
+	tuitest.NewDriver(t, clock).Snapshot("one strike").RequireGolden()
+}
+
+func TestClockASCII(t *testing.T) {
+	mod := &pb.Module{Id: "clock", Type: pb.Module_CLOCK}
+	start := time.Now()
+	clock := NewClockModule(mod, func() time.Time { return start }, start, 5*time.Minute, 0, 3, true)
+
+	tuitest.NewDriver(t, clock).Snapshot("ascii digits").RequireGolden()
+}
@@ -1,7 +1,6 @@
 package modules
 
 import (
-	"context"
 	"fmt"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,6 +12,8 @@ import (
 )
 
 type KeypadModule struct {
+	noLifecycle
+
 	mod       *pb.Module
 	client    client.GameClient
 	sessionID string
@@ -83,6 +84,8 @@ func (m *KeypadModule) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return BackToBombMsg{}
 			}
 		}
+	case ModuleResultMsg:
+		m.handleResult(msg)
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -91,50 +94,52 @@ func (m *KeypadModule) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *KeypadModule) activateSymbol(pos int) tea.Cmd {
-	return func() tea.Msg {
-		state := m.mod.GetKeypadState()
-		if state == nil {
-			return ModuleResultMsg{Err: fmt.Errorf("no keypad state")}
-		}
-
-		symbols := state.GetDisplayedSymbols()
-		if pos < 0 || pos >= len(symbols) {
-			return ModuleResultMsg{Err: fmt.Errorf("invalid position")}
-		}
+	state := m.mod.GetKeypadState()
+	if state == nil {
+		return inputError(m.mod.GetId(), fmt.Errorf("no keypad state"))
+	}
 
-		if m.activatedSymbols[pos] {
-			return ModuleResultMsg{Err: fmt.Errorf("symbol already activated")}
-		}
+	symbols := state.GetDisplayedSymbols()
+	if pos < 0 || pos >= len(symbols) {
+		return inputError(m.mod.GetId(), fmt.Errorf("invalid position"))
+	}
 
-		symbol := symbols[pos]
+	if m.activatedSymbols[pos] {
+		return inputError(m.mod.GetId(), fmt.Errorf("symbol already activated"))
+	}
 
-		input := &pb.PlayerInput{
-			SessionId: m.sessionID,
-			BombId:    m.bombID,
-			ModuleId:  m.mod.GetId(),
-			Input:     &pb.PlayerInput_KeypadInput{KeypadInput: &pb.KeypadInput{Symbol: symbol}},
-		}
+	return sendInput(m.client, &pb.PlayerInput{
+		SessionId: m.sessionID,
+		BombId:    m.bombID,
+		ModuleId:  m.mod.GetId(),
+		Input:     &pb.PlayerInput_KeypadInput{KeypadInput: &pb.KeypadInput{Symbol: symbols[pos]}},
+	})
+}
 
-		result, err := m.client.SendInput(context.Background(), input)
-		if err != nil {
-			return ModuleResultMsg{Err: err}
-		}
+func (m *KeypadModule) handleResult(msg ModuleResultMsg) {
+	if msg.Err != nil {
+		return
+	}
 
-		m.activatedSymbols[pos] = true
-
-		if result.GetStrike() {
-			m.message = "STRIKE! Wrong symbol!"
-			m.messageType = "error"
-			m.activatedSymbols = make(map[int]bool)
-		} else if result.GetSolved() {
-			m.message = "Module solved!"
-			m.messageType = "success"
-		} else {
-			m.message = ""
-			m.messageType = ""
+	symbol := msg.Input.GetKeypadInput().GetSymbol()
+	for pos, displayed := range m.mod.GetKeypadState().GetDisplayedSymbols() {
+		if displayed == symbol {
+			m.activatedSymbols[pos] = true
+			break
 		}
+	}
 
-		return ModuleResultMsg{Result: result}
+	result := msg.Result
+	if result.GetStrike() {
+		m.message = "STRIKE! Wrong symbol!"
+		m.messageType = "error"
+		m.activatedSymbols = make(map[int]bool)
+	} else if result.GetSolved() {
+		m.message = "Module solved!"
+		m.messageType = "success"
+	} else {
+		m.message = ""
+		m.messageType = ""
 	}
 }
 
@@ -192,7 +197,7 @@ func (m *KeypadModule) View() string {
 	}
 
 	return lipgloss.NewStyle().
-		Width(60).
+		Width(viewWidth(m.width)).
 		Align(lipgloss.Center).
 		Render(content)
 }
@@ -205,7 +210,7 @@ func renderKeypadButton(symbol string, activated bool, position int) string {
 		Height(3)
 
 	if activated {
-		boxStyle = boxStyle.BorderForeground(lipgloss.Color("#6BCB77"))
+		boxStyle = boxStyle.BorderForeground(styles.Colors.Green)
 	}
 
 	symbolStyle := lipgloss.NewStyle().
@@ -215,7 +220,7 @@ func renderKeypadButton(symbol string, activated bool, position int) string {
 		Bold(true)
 
 	if activated {
-		symbolStyle = symbolStyle.Foreground(lipgloss.Color("#6BCB77"))
+		symbolStyle = symbolStyle.Foreground(styles.Colors.Green)
 	}
 
 	positionLabel := fmt.Sprintf("[%d]", position)
@@ -1,7 +1,6 @@
 package modules
 
 import (
-	"context"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -12,6 +11,8 @@ import (
 )
 
 type MazeModule struct {
+	noLifecycle
+
 	mod       *pb.Module
 	client    client.GameClient
 	sessionID string
@@ -90,6 +91,8 @@ func (m *MazeModule) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return BackToBombMsg{}
 			}
 		}
+	case ModuleResultMsg:
+		m.handleResult(msg)
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -98,39 +101,37 @@ func (m *MazeModule) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *MazeModule) move(direction pb.CardinalDirection) tea.Cmd {
-	return func() tea.Msg {
-		input := &pb.PlayerInput{
-			SessionId: m.sessionID,
-			BombId:    m.bombID,
-			ModuleId:  m.mod.GetId(),
-			Input: &pb.PlayerInput_MazeInput{
-				MazeInput: &pb.MazeInput{
-					Direction: direction,
-				},
+	return sendInput(m.client, &pb.PlayerInput{
+		SessionId: m.sessionID,
+		BombId:    m.bombID,
+		ModuleId:  m.mod.GetId(),
+		Input: &pb.PlayerInput_MazeInput{
+			MazeInput: &pb.MazeInput{
+				Direction: direction,
 			},
-		}
+		},
+	})
+}
 
-		result, err := m.client.SendInput(context.Background(), input)
-		if err != nil {
-			return ModuleResultMsg{Err: err}
-		}
+func (m *MazeModule) handleResult(msg ModuleResultMsg) {
+	if msg.Err != nil {
+		return
+	}
 
-		if result.GetSolved() {
-			m.message = "Module solved!"
-			m.messageType = "success"
-		} else if result.GetStrike() {
-			m.message = "STRIKE! Invalid move!"
-			m.messageType = "error"
-		}
+	result := msg.Result
+	if result.GetSolved() {
+		m.message = "Module solved!"
+		m.messageType = "success"
+	} else if result.GetStrike() {
+		m.message = "STRIKE! Invalid move!"
+		m.messageType = "error"
+	}
 
-		if mazeResult := result.GetMazeInputResult(); mazeResult != nil {
-			if mazeState := mazeResult.GetMazeState(); mazeState != nil {
-				m.mod.State = &pb.Module_MazeState{MazeState: mazeState}
-				m.updatePositionsFromState()
-			}
+	if mazeResult := result.GetMazeInputResult(); mazeResult != nil {
+		if mazeState := mazeResult.GetMazeState(); mazeState != nil {
+			m.mod.State = &pb.Module_MazeState{MazeState: mazeState}
+			m.updatePositionsFromState()
 		}
-
-		return ModuleResultMsg{Result: result}
 	}
 }
 
@@ -138,7 +139,7 @@ func (m *MazeModule) View() string {
 	grid := m.renderGrid()
 
 	content := lipgloss.NewStyle().
-		Width(60).
+		Width(viewWidth(m.width)).
 		Align(lipgloss.Center).
 		Render(
 			lipgloss.JoinVertical(
@@ -152,7 +153,7 @@ func (m *MazeModule) View() string {
 	if m.message != "" {
 		if m.messageType == "error" {
 			content = lipgloss.NewStyle().
-				Width(60).
+				Width(viewWidth(m.width)).
 				Align(lipgloss.Center).
 				Render(
 					lipgloss.JoinVertical(
@@ -163,7 +164,7 @@ func (m *MazeModule) View() string {
 				)
 		} else if m.messageType == "success" {
 			content = lipgloss.NewStyle().
-				Width(60).
+				Width(viewWidth(m.width)).
 				Align(lipgloss.Center).
 				Render(
 					lipgloss.JoinVertical(
@@ -204,13 +205,13 @@ func (m *MazeModule) renderCell(x, y int) string {
 
 	switch {
 	case x == m.playerX && y == m.playerY:
-		return cellStyle.Foreground(lipgloss.Color("#FFFFFF")).Background(lipgloss.Color("#4DABF7")).Render("●")
+		return cellStyle.Foreground(styles.Colors.White).Background(styles.Colors.Blue).Render("●")
 	case x == m.goalX && y == m.goalY:
-		return cellStyle.Foreground(lipgloss.Color("#FF6B6B")).Render("▲")
+		return cellStyle.Foreground(styles.Colors.Red).Render("▲")
 	case (x == m.marker1X && y == m.marker1Y) || (x == m.marker2X && y == m.marker2Y):
-		return cellStyle.Foreground(lipgloss.Color("#6BCB77")).Render("◎")
+		return cellStyle.Foreground(styles.Colors.Green).Render("◎")
 	default:
-		return cellStyle.Foreground(lipgloss.Color("#666666")).Render("○")
+		return cellStyle.Foreground(styles.Colors.Faint).Render("○")
 	}
 }
 
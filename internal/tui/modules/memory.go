@@ -1,7 +1,6 @@
 package modules
 
 import (
-	"context"
 	"fmt"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,6 +12,8 @@ import (
 )
 
 type MemoryModule struct {
+	noLifecycle
+
 	mod       *pb.Module
 	client    client.GameClient
 	sessionID string
@@ -49,6 +50,8 @@ func (m *MemoryModule) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return BackToBombMsg{}
 			}
 		}
+	case ModuleResultMsg:
+		m.handleResult(msg)
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -57,46 +60,43 @@ func (m *MemoryModule) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *MemoryModule) pressButton(index int) tea.Cmd {
-	return func() tea.Msg {
-		state := m.mod.GetMemoryState()
-		if state == nil {
-			return ModuleResultMsg{Err: fmt.Errorf("no memory state")}
-		}
+	if m.mod.GetMemoryState() == nil {
+		return inputError(m.mod.GetId(), fmt.Errorf("no memory state"))
+	}
 
-		input := &pb.PlayerInput{
-			SessionId: m.sessionID,
-			BombId:    m.bombID,
-			ModuleId:  m.mod.GetId(),
-			Input: &pb.PlayerInput_MemoryInput{
-				MemoryInput: &pb.MemoryInput{
-					ButtonIndex: int32(index),
-				},
+	return sendInput(m.client, &pb.PlayerInput{
+		SessionId: m.sessionID,
+		BombId:    m.bombID,
+		ModuleId:  m.mod.GetId(),
+		Input: &pb.PlayerInput_MemoryInput{
+			MemoryInput: &pb.MemoryInput{
+				ButtonIndex: int32(index),
 			},
-		}
-
-		result, err := m.client.SendInput(context.Background(), input)
-		if err != nil {
-			return ModuleResultMsg{Err: err}
-		}
+		},
+	})
+}
 
-		if memResult := result.GetMemoryInputResult(); memResult != nil {
-			if memState := memResult.GetMemoryState(); memState != nil {
-				m.mod.State = &pb.Module_MemoryState{MemoryState: memState}
-			}
-		}
+func (m *MemoryModule) handleResult(msg ModuleResultMsg) {
+	if msg.Err != nil {
+		return
+	}
 
-		if result.GetStrike() {
-			m.message = "STRIKE!"
-			m.messageType = "error"
-		} else if result.GetSolved() {
-			m.message = "Module solved!"
-			m.messageType = "success"
-		} else {
-			m.message = ""
-			m.messageType = ""
+	result := msg.Result
+	if memResult := result.GetMemoryInputResult(); memResult != nil {
+		if memState := memResult.GetMemoryState(); memState != nil {
+			m.mod.State = &pb.Module_MemoryState{MemoryState: memState}
 		}
+	}
 
-		return ModuleResultMsg{Result: result}
+	if result.GetStrike() {
+		m.message = "STRIKE!"
+		m.messageType = "error"
+	} else if result.GetSolved() {
+		m.message = "Module solved!"
+		m.messageType = "success"
+	} else {
+		m.message = ""
+		m.messageType = ""
 	}
 }
 
@@ -112,7 +112,7 @@ func (m *MemoryModule) View() string {
 
 	screenStyle := lipgloss.NewStyle().
 		Border(lipgloss.DoubleBorder()).
-		BorderForeground(lipgloss.Color("#4ECDC4")).
+		BorderForeground(styles.Colors.Cyan).
 		Padding(1, 2).
 		Width(6).
 		Height(3)
@@ -180,7 +180,7 @@ func (m *MemoryModule) View() string {
 	)
 
 	content := lipgloss.NewStyle().
-		Width(60).
+		Width(viewWidth(m.width)).
 		Align(lipgloss.Center).
 		Render(
 			lipgloss.JoinVertical(
@@ -194,7 +194,7 @@ func (m *MemoryModule) View() string {
 	if m.message != "" {
 		if m.messageType == "error" {
 			content = lipgloss.NewStyle().
-				Width(60).
+				Width(viewWidth(m.width)).
 				Align(lipgloss.Center).
 				Render(
 					lipgloss.JoinVertical(
@@ -205,7 +205,7 @@ func (m *MemoryModule) View() string {
 				)
 		} else if m.messageType == "success" {
 			content = lipgloss.NewStyle().
-				Width(60).
+				Width(viewWidth(m.width)).
 				Align(lipgloss.Center).
 				Render(
 					lipgloss.JoinVertical(
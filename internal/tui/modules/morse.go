@@ -1,7 +1,6 @@
 package modules
 
 import (
-	"context"
 	"fmt"
 	"math"
 	"strings"
@@ -60,6 +59,8 @@ type MorseModule struct {
 
 	startTime time.Time
 	lightOn   bool
+	tickSeq   int
+	animating bool
 
 	message     string
 	messageType string
@@ -112,22 +113,35 @@ func (m *MorseModule) calculateTimings() {
 }
 
 func (m *MorseModule) Init() tea.Cmd {
-	return tea.Tick(TICK_INTERVAL, func(t time.Time) tea.Msg {
-		return MorseTickMsg{Time: t}
-	})
+	return nil
 }
 
 type MorseTickMsg struct {
-	Time time.Time
+	ModuleID string
+	Seq      int
+	Time     time.Time
+}
+
+func (msg MorseTickMsg) TargetModuleID() string {
+	return msg.ModuleID
+}
+
+func (m *MorseModule) tick() tea.Cmd {
+	moduleID := m.mod.GetId()
+	seq := m.tickSeq
+	return tea.Tick(TICK_INTERVAL, func(t time.Time) tea.Msg {
+		return MorseTickMsg{ModuleID: moduleID, Seq: seq, Time: t}
+	})
 }
 
 func (m *MorseModule) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case MorseTickMsg:
+		if !m.animating || msg.Seq != m.tickSeq {
+			return m, nil
+		}
 		m.updateAnimation()
-		return m, tea.Tick(TICK_INTERVAL, func(t time.Time) tea.Msg {
-			return MorseTickMsg{Time: t}
-		})
+		return m, m.tick()
 
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -143,6 +157,8 @@ func (m *MorseModule) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case ModuleResultMsg:
+		m.handleResult(msg)
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -183,83 +199,75 @@ func (m *MorseModule) updateAnimation() {
 }
 
 func (m *MorseModule) changeFrequency(direction pb.IncrementDecrement) tea.Cmd {
-	return func() tea.Msg {
-		input := &pb.PlayerInput{
-			SessionId: m.sessionID,
-			BombId:    m.bombID,
-			ModuleId:  m.mod.GetId(),
-			Input: &pb.PlayerInput_MorseInput{
-				MorseInput: &pb.MorseInput{
-					Input: &pb.MorseInput_FrequencyChange{
-						FrequencyChange: &pb.MorseFrequencyChange{
-							Direction: direction,
-						},
+	return sendInput(m.client, &pb.PlayerInput{
+		SessionId: m.sessionID,
+		BombId:    m.bombID,
+		ModuleId:  m.mod.GetId(),
+		Input: &pb.PlayerInput_MorseInput{
+			MorseInput: &pb.MorseInput{
+				Input: &pb.MorseInput_FrequencyChange{
+					FrequencyChange: &pb.MorseFrequencyChange{
+						Direction: direction,
 					},
 				},
 			},
-		}
-
-		result, err := m.client.SendInput(context.Background(), input)
-		if err != nil {
-			return ModuleResultMsg{Err: err}
-		}
-
-		if morseResult := result.GetMorseInputResult(); morseResult != nil {
-			if morseState := morseResult.GetMorseState(); morseState != nil {
-				oldPattern := m.pattern
-				m.state = morseState
-				m.pattern = morseState.GetDisplayedPattern()
-
-				if m.pattern != "" && m.pattern != oldPattern {
-					m.calculateTimings()
-				}
-			}
-		}
-
-		if result.GetStrike() {
-			m.message = "STRIKE!"
-			m.messageType = "error"
-		} else {
-			m.message = ""
-			m.messageType = ""
-		}
-
-		return ModuleResultMsg{Result: result}
-	}
+		},
+	})
 }
 
 func (m *MorseModule) transmit() tea.Cmd {
-	return func() tea.Msg {
-		input := &pb.PlayerInput{
-			SessionId: m.sessionID,
-			BombId:    m.bombID,
-			ModuleId:  m.mod.GetId(),
-			Input: &pb.PlayerInput_MorseInput{
-				MorseInput: &pb.MorseInput{
-					Input: &pb.MorseInput_Tx{
-						Tx: &pb.MorseTx{},
-					},
+	return sendInput(m.client, &pb.PlayerInput{
+		SessionId: m.sessionID,
+		BombId:    m.bombID,
+		ModuleId:  m.mod.GetId(),
+		Input: &pb.PlayerInput_MorseInput{
+			MorseInput: &pb.MorseInput{
+				Input: &pb.MorseInput_Tx{
+					Tx: &pb.MorseTx{},
 				},
 			},
-		}
+		},
+	})
+}
+
+func (m *MorseModule) handleResult(msg ModuleResultMsg) {
+	if msg.Err != nil {
+		return
+	}
+
+	result := msg.Result
+	if morseResult := result.GetMorseInputResult(); morseResult != nil {
+		if morseState := morseResult.GetMorseState(); morseState != nil {
+			oldPattern := m.pattern
+			m.state = morseState
+			m.pattern = morseState.GetDisplayedPattern()
 
-		result, err := m.client.SendInput(context.Background(), input)
-		if err != nil {
-			return ModuleResultMsg{Err: err}
+			if m.pattern != "" && m.pattern != oldPattern {
+				m.calculateTimings()
+			}
 		}
+	}
 
+	if msg.Input.GetMorseInput().GetTx() == nil {
 		if result.GetStrike() {
-			m.message = "STRIKE! Wrong frequency!"
+			m.message = "STRIKE!"
 			m.messageType = "error"
-		} else if result.GetSolved() {
-			m.message = "Module solved!"
-			m.messageType = "success"
 		} else {
 			m.message = ""
 			m.messageType = ""
 		}
+		return
+	}
 
-		return ModuleResultMsg{Result: result}
+	if result.GetStrike() {
+		m.message = "STRIKE! Wrong frequency!"
+		m.messageType = "error"
+	} else if result.GetSolved() {
+		m.message = "Module solved!"
+		m.messageType = "success"
+	} else {
+		m.message = ""
+		m.messageType = ""
 	}
 }
 
@@ -305,14 +313,14 @@ func (m *MorseModule) View() string {
 	}
 
 	return lipgloss.NewStyle().
-		Width(60).
+		Width(viewWidth(m.width)).
 		Align(lipgloss.Center).
 		Render(content)
 }
 
 func (m *MorseModule) renderLight() string {
-	amberColor := lipgloss.Color("#FFB000")
-	wireColor := lipgloss.Color("#8B4513")
+	amberColor := styles.Colors.Lamp
+	wireColor := styles.Colors.Wire
 
 	if m.lightOn {
 		light := lipgloss.NewStyle().
@@ -339,16 +347,16 @@ func (m *MorseModule) renderLight() string {
 func (m *MorseModule) renderFrequency(idx int32, frequency float32) string {
 	freqLabel := styles.Subtitle.Render("FREQUENCY")
 	freqValue := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#FFD700")).
+		Foreground(styles.Colors.Readout).
 		Bold(true).
 		Render(fmt.Sprintf("%.3f MHz", frequency))
 
 	arrows := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#888888")).
+		Foreground(styles.Colors.Trim).
 		Render("◄──")
 
 	arrowRight := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#888888")).
+		Foreground(styles.Colors.Trim).
 		Render("──►")
 
 	freqRow := lipgloss.JoinHorizontal(
@@ -364,7 +372,7 @@ func (m *MorseModule) renderFrequency(idx int32, frequency float32) string {
 
 	box := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#555555")).
+		BorderForeground(styles.Colors.Casing).
 		Padding(1, 2).
 		Render(
 			lipgloss.JoinVertical(
@@ -396,7 +404,7 @@ func (m *MorseModule) renderSlider(idx int32) string {
 	}
 
 	sliderBar := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#FFD700")).
+		Foreground(styles.Colors.Readout).
 		Render(sb.String())
 
 	return sliderBar
@@ -404,24 +412,35 @@ func (m *MorseModule) renderSlider(idx int32) string {
 
 func (m *MorseModule) renderTXButton() string {
 	txLabel := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#222222")).
+		Foreground(styles.Colors.Engraving).
 		Bold(true).
 		Render("  TX  ")
 
 	button := lipgloss.NewStyle().
-		Background(lipgloss.Color("#FF6B6B")).
+		Background(styles.Colors.Red).
 		Padding(0, 2).
 		Render(txLabel)
 
 	box := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#FF6B6B")).
+		BorderForeground(styles.Colors.Red).
 		Align(lipgloss.Center).
 		Render(button)
 
 	return box
 }
 
+func (m *MorseModule) OnEnter() tea.Cmd {
+	m.tickSeq++
+	m.animating = true
+	m.updateAnimation()
+	return m.tick()
+}
+
+func (m *MorseModule) OnLeave() {
+	m.animating = false
+}
+
 func (m *MorseModule) ID() string {
 	return m.mod.GetId()
 }
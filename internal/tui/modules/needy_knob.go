@@ -1,7 +1,6 @@
 package modules
 
 import (
-	"context"
 	"fmt"
 	"time"
 
@@ -18,6 +17,8 @@ const (
 )
 
 type NeedyKnobModule struct {
+	noLifecycle
+
 	mod       *pb.Module
 	client    client.GameClient
 	sessionID string
@@ -58,21 +59,31 @@ func NewNeedyKnobModule(mod *pb.Module, client client.GameClient, sessionID, bom
 }
 
 type NeedyKnobTickMsg struct {
-	Time time.Time
+	ModuleID string
+	Time     time.Time
+}
+
+func (msg NeedyKnobTickMsg) TargetModuleID() string {
+	return msg.ModuleID
 }
 
+// Init starts the countdown tick. It keeps running while the module is in
+// the background so the timer never freezes.
 func (m *NeedyKnobModule) Init() tea.Cmd {
+	return m.tick()
+}
+
+func (m *NeedyKnobModule) tick() tea.Cmd {
+	moduleID := m.mod.GetId()
 	return tea.Tick(KNOB_TICK_INTERVAL, func(t time.Time) tea.Msg {
-		return NeedyKnobTickMsg{Time: t}
+		return NeedyKnobTickMsg{ModuleID: moduleID, Time: t}
 	})
 }
 
 func (m *NeedyKnobModule) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case NeedyKnobTickMsg:
-		return m, tea.Tick(KNOB_TICK_INTERVAL, func(t time.Time) tea.Msg {
-			return NeedyKnobTickMsg{Time: t}
-		})
+		return m, m.tick()
 
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -84,6 +95,8 @@ func (m *NeedyKnobModule) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case ModuleResultMsg:
+		m.handleResult(msg)
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -93,41 +106,39 @@ func (m *NeedyKnobModule) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *NeedyKnobModule) sendRotate() tea.Cmd {
-	return func() tea.Msg {
-		input := &pb.PlayerInput{
-			SessionId: m.sessionID,
-			BombId:    m.bombID,
-			ModuleId:  m.mod.GetId(),
-			Input: &pb.PlayerInput_NeedyKnobInput{
-				NeedyKnobInput: &pb.NeedyKnobInput{},
-			},
-		}
-
-		result, err := m.client.SendInput(context.Background(), input)
-		if err != nil {
-			return ModuleResultMsg{Err: err}
-		}
+	return sendInput(m.client, &pb.PlayerInput{
+		SessionId: m.sessionID,
+		BombId:    m.bombID,
+		ModuleId:  m.mod.GetId(),
+		Input: &pb.PlayerInput_NeedyKnobInput{
+			NeedyKnobInput: &pb.NeedyKnobInput{},
+		},
+	})
+}
 
-		// Update state from result
-		if knobResult := result.GetNeedyKnobInputResult(); knobResult != nil {
-			if knobState := knobResult.GetNeedyKnobState(); knobState != nil {
-				m.displayedPatternFirstRow = knobState.GetDisplayedPatternFirstRow()
-				m.displayedPatternSecondRow = knobState.GetDisplayedPatternSecondRow()
-				m.dialDirection = knobState.GetDialDirection()
-				m.countdownStartedAt = knobState.GetCountdownStartedAt()
-				m.countdownDuration = knobState.GetCountdownDuration()
-			}
-		}
+func (m *NeedyKnobModule) handleResult(msg ModuleResultMsg) {
+	if msg.Err != nil {
+		return
+	}
 
-		if result.GetStrike() {
-			m.message = "STRIKE!"
-			m.messageType = "error"
-		} else {
-			m.message = ""
-			m.messageType = ""
+	result := msg.Result
+	// Update state from result
+	if knobResult := result.GetNeedyKnobInputResult(); knobResult != nil {
+		if knobState := knobResult.GetNeedyKnobState(); knobState != nil {
+			m.displayedPatternFirstRow = knobState.GetDisplayedPatternFirstRow()
+			m.displayedPatternSecondRow = knobState.GetDisplayedPatternSecondRow()
+			m.dialDirection = knobState.GetDialDirection()
+			m.countdownStartedAt = knobState.GetCountdownStartedAt()
+			m.countdownDuration = knobState.GetCountdownDuration()
 		}
+	}
 
-		return ModuleResultMsg{Result: result}
+	if result.GetStrike() {
+		m.message = "STRIKE!"
+		m.messageType = "error"
+	} else {
+		m.message = ""
+		m.messageType = ""
 	}
 }
 
@@ -146,6 +157,14 @@ func (m *NeedyKnobModule) getRemainingTime() int {
 	return remaining
 }
 
+func (m *NeedyKnobModule) Remaining() (time.Duration, bool) {
+	remaining := m.getRemainingTime()
+	if remaining < 0 {
+		return 0, false
+	}
+	return time.Duration(remaining) * time.Second, true
+}
+
 func (m *NeedyKnobModule) View() string {
 	timer := m.renderTimer()
 	dial := m.renderDial()
@@ -174,7 +193,7 @@ func (m *NeedyKnobModule) View() string {
 	}
 
 	return lipgloss.NewStyle().
-		Width(60).
+		Width(viewWidth(m.width)).
 		Align(lipgloss.Center).
 		Render(content)
 }
@@ -183,7 +202,7 @@ func (m *NeedyKnobModule) renderTimer() string {
 	remaining := m.getRemainingTime()
 
 	redStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#FF0000")).
+		Foreground(styles.Colors.Display).
 		Bold(true)
 
 	var timerText string
@@ -197,7 +216,7 @@ func (m *NeedyKnobModule) renderTimer() string {
 
 	box := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#555555")).
+		BorderForeground(styles.Colors.Casing).
 		Padding(0, 2).
 		Render(timerDisplay)
 
@@ -231,7 +250,7 @@ func (m *NeedyKnobModule) renderDial() string {
 
 	box := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#888888")).
+		BorderForeground(styles.Colors.Trim).
 		Padding(0, 1).
 		Align(lipgloss.Center).
 		Render(dialContent)
@@ -241,10 +260,10 @@ func (m *NeedyKnobModule) renderDial() string {
 
 func (m *NeedyKnobModule) renderLEDs() string {
 	litStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#6BCB77"))
+		Foreground(styles.Colors.Green)
 
 	unlitStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#555555"))
+		Foreground(styles.Colors.Casing)
 
 	// Render first row (3 + space + 3)
 	row1 := ""
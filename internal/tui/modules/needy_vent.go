@@ -1,7 +1,6 @@
 package modules
 
 import (
-	"context"
 	"fmt"
 	"time"
 
@@ -18,6 +17,8 @@ const (
 )
 
 type NeedyVentGasModule struct {
+	noLifecycle
+
 	mod       *pb.Module
 	client    client.GameClient
 	sessionID string
@@ -54,21 +55,31 @@ func NewNeedyVentGasModule(mod *pb.Module, client client.GameClient, sessionID,
 }
 
 type NeedyVentTickMsg struct {
-	Time time.Time
+	ModuleID string
+	Time     time.Time
+}
+
+func (msg NeedyVentTickMsg) TargetModuleID() string {
+	return msg.ModuleID
 }
 
+// Init starts the countdown tick. It keeps running while the module is in
+// the background so the timer never freezes.
 func (m *NeedyVentGasModule) Init() tea.Cmd {
+	return m.tick()
+}
+
+func (m *NeedyVentGasModule) tick() tea.Cmd {
+	moduleID := m.mod.GetId()
 	return tea.Tick(VENT_TICK_INTERVAL, func(t time.Time) tea.Msg {
-		return NeedyVentTickMsg{Time: t}
+		return NeedyVentTickMsg{ModuleID: moduleID, Time: t}
 	})
 }
 
 func (m *NeedyVentGasModule) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case NeedyVentTickMsg:
-		return m, tea.Tick(VENT_TICK_INTERVAL, func(t time.Time) tea.Msg {
-			return NeedyVentTickMsg{Time: t}
-		})
+		return m, m.tick()
 
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -82,6 +93,8 @@ func (m *NeedyVentGasModule) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case ModuleResultMsg:
+		m.handleResult(msg)
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -91,41 +104,39 @@ func (m *NeedyVentGasModule) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *NeedyVentGasModule) sendAnswer(answer bool) tea.Cmd {
-	return func() tea.Msg {
-		input := &pb.PlayerInput{
-			SessionId: m.sessionID,
-			BombId:    m.bombID,
-			ModuleId:  m.mod.GetId(),
-			Input: &pb.PlayerInput_NeedyVentGasInput{
-				NeedyVentGasInput: &pb.NeedyVentGasInput{
-					Input: answer,
-				},
+	return sendInput(m.client, &pb.PlayerInput{
+		SessionId: m.sessionID,
+		BombId:    m.bombID,
+		ModuleId:  m.mod.GetId(),
+		Input: &pb.PlayerInput_NeedyVentGasInput{
+			NeedyVentGasInput: &pb.NeedyVentGasInput{
+				Input: answer,
 			},
-		}
-
-		result, err := m.client.SendInput(context.Background(), input)
-		if err != nil {
-			return ModuleResultMsg{Err: err}
-		}
+		},
+	})
+}
 
-		// Update state from result
-		if ventResult := result.GetNeedyVentGasInputResult(); ventResult != nil {
-			if ventState := ventResult.GetNeedyVentGasState(); ventState != nil {
-				m.displayedQuestion = ventState.GetDisplayedQuestion()
-				m.countdownStartedAt = ventState.GetCountdownStartedAt()
-				m.countdownDuration = ventState.GetCountdownDuration()
-			}
-		}
+func (m *NeedyVentGasModule) handleResult(msg ModuleResultMsg) {
+	if msg.Err != nil {
+		return
+	}
 
-		if result.GetStrike() {
-			m.message = "STRIKE!"
-			m.messageType = "error"
-		} else {
-			m.message = ""
-			m.messageType = ""
+	result := msg.Result
+	// Update state from result
+	if ventResult := result.GetNeedyVentGasInputResult(); ventResult != nil {
+		if ventState := ventResult.GetNeedyVentGasState(); ventState != nil {
+			m.displayedQuestion = ventState.GetDisplayedQuestion()
+			m.countdownStartedAt = ventState.GetCountdownStartedAt()
+			m.countdownDuration = ventState.GetCountdownDuration()
 		}
+	}
 
-		return ModuleResultMsg{Result: result}
+	if result.GetStrike() {
+		m.message = "STRIKE!"
+		m.messageType = "error"
+	} else {
+		m.message = ""
+		m.messageType = ""
 	}
 }
 
@@ -144,6 +155,14 @@ func (m *NeedyVentGasModule) getRemainingTime() int {
 	return remaining
 }
 
+func (m *NeedyVentGasModule) Remaining() (time.Duration, bool) {
+	remaining := m.getRemainingTime()
+	if remaining < 0 {
+		return 0, false
+	}
+	return time.Duration(remaining) * time.Second, true
+}
+
 func (m *NeedyVentGasModule) View() string {
 	timer := m.renderTimer()
 	question := m.renderQuestion()
@@ -172,7 +191,7 @@ func (m *NeedyVentGasModule) View() string {
 	}
 
 	return lipgloss.NewStyle().
-		Width(60).
+		Width(viewWidth(m.width)).
 		Align(lipgloss.Center).
 		Render(content)
 }
@@ -181,7 +200,7 @@ func (m *NeedyVentGasModule) renderTimer() string {
 	remaining := m.getRemainingTime()
 
 	redStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#FF0000")).
+		Foreground(styles.Colors.Display).
 		Bold(true)
 
 	var timerText string
@@ -195,7 +214,7 @@ func (m *NeedyVentGasModule) renderTimer() string {
 
 	box := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#555555")).
+		BorderForeground(styles.Colors.Casing).
 		Padding(0, 2).
 		Render(timerDisplay)
 
@@ -204,7 +223,7 @@ func (m *NeedyVentGasModule) renderTimer() string {
 
 func (m *NeedyVentGasModule) renderQuestion() string {
 	greenStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#5B8C45")).
+		Foreground(styles.Colors.Screen).
 		Bold(true)
 
 	var questionText string
@@ -218,7 +237,7 @@ func (m *NeedyVentGasModule) renderQuestion() string {
 
 	box := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#5B8C45")).
+		BorderForeground(styles.Colors.Screen).
 		Padding(1, 3).
 		Align(lipgloss.Center).
 		Render(questionDisplay)
@@ -229,13 +248,13 @@ func (m *NeedyVentGasModule) renderQuestion() string {
 func (m *NeedyVentGasModule) renderButtons() string {
 	yButton := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#888888")).
+		BorderForeground(styles.Colors.Trim).
 		Padding(0, 2).
 		Render("Y")
 
 	nButton := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#888888")).
+		BorderForeground(styles.Colors.Trim).
 		Padding(0, 2).
 		Render("N")
 
@@ -1,7 +1,6 @@
 package modules
 
 import (
-	"context"
 	"fmt"
 	"strings"
 
@@ -14,6 +13,8 @@ import (
 )
 
 type PasswordModule struct {
+	noLifecycle
+
 	mod       *pb.Module
 	client    client.GameClient
 	sessionID string
@@ -60,6 +61,8 @@ func (m *PasswordModule) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return BackToBombMsg{}
 			}
 		}
+	case ModuleResultMsg:
+		m.handleResult(msg)
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -68,88 +71,78 @@ func (m *PasswordModule) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *PasswordModule) changeLetter(col int, direction pb.IncrementDecrement) tea.Cmd {
-	return func() tea.Msg {
-		state := m.mod.GetPasswordState()
-		if state == nil {
-			return ModuleResultMsg{Err: fmt.Errorf("no password state")}
-		}
+	if m.mod.GetPasswordState() == nil {
+		return inputError(m.mod.GetId(), fmt.Errorf("no password state"))
+	}
 
-		input := &pb.PlayerInput{
-			SessionId: m.sessionID,
-			BombId:    m.bombID,
-			ModuleId:  m.mod.GetId(),
-			Input: &pb.PlayerInput_PasswordInput{
-				PasswordInput: &pb.PasswordInput{
-					Input: &pb.PasswordInput_LetterChange{
-						LetterChange: &pb.LetterChange{
-							LetterIndex: int32(col),
-							Direction:   direction,
-						},
+	return sendInput(m.client, &pb.PlayerInput{
+		SessionId: m.sessionID,
+		BombId:    m.bombID,
+		ModuleId:  m.mod.GetId(),
+		Input: &pb.PlayerInput_PasswordInput{
+			PasswordInput: &pb.PasswordInput{
+				Input: &pb.PasswordInput_LetterChange{
+					LetterChange: &pb.LetterChange{
+						LetterIndex: int32(col),
+						Direction:   direction,
 					},
 				},
 			},
-		}
-
-		result, err := m.client.SendInput(context.Background(), input)
-		if err != nil {
-			return ModuleResultMsg{Err: err}
-		}
-
-		if pwdResult := result.GetPasswordInputResult(); pwdResult != nil {
-			if pwdState := pwdResult.GetPasswordState(); pwdState != nil {
-				m.mod.State = &pb.Module_PasswordState{PasswordState: pwdState}
-			}
-		}
-
-		if result.GetStrike() {
-			m.message = "STRIKE!"
-			m.messageType = "error"
-		} else {
-			m.message = ""
-			m.messageType = ""
-		}
-
-		return ModuleResultMsg{Result: result}
-	}
+		},
+	})
 }
 
 func (m *PasswordModule) submit() tea.Cmd {
-	return func() tea.Msg {
-		state := m.mod.GetPasswordState()
-		if state == nil {
-			return ModuleResultMsg{Err: fmt.Errorf("no password state")}
-		}
+	if m.mod.GetPasswordState() == nil {
+		return inputError(m.mod.GetId(), fmt.Errorf("no password state"))
+	}
 
-		input := &pb.PlayerInput{
-			SessionId: m.sessionID,
-			BombId:    m.bombID,
-			ModuleId:  m.mod.GetId(),
-			Input: &pb.PlayerInput_PasswordInput{
-				PasswordInput: &pb.PasswordInput{
-					Input: &pb.PasswordInput_Submit{
-						Submit: &pb.PasswordSubmit{},
-					},
+	return sendInput(m.client, &pb.PlayerInput{
+		SessionId: m.sessionID,
+		BombId:    m.bombID,
+		ModuleId:  m.mod.GetId(),
+		Input: &pb.PlayerInput_PasswordInput{
+			PasswordInput: &pb.PasswordInput{
+				Input: &pb.PasswordInput_Submit{
+					Submit: &pb.PasswordSubmit{},
 				},
 			},
-		}
+		},
+	})
+}
+
+func (m *PasswordModule) handleResult(msg ModuleResultMsg) {
+	if msg.Err != nil {
+		return
+	}
 
-		result, err := m.client.SendInput(context.Background(), input)
-		if err != nil {
-			return ModuleResultMsg{Err: err}
+	result := msg.Result
+	if pwdResult := result.GetPasswordInputResult(); pwdResult != nil {
+		if pwdState := pwdResult.GetPasswordState(); pwdState != nil {
+			m.mod.State = &pb.Module_PasswordState{PasswordState: pwdState}
 		}
+	}
 
+	if msg.Input.GetPasswordInput().GetSubmit() == nil {
 		if result.GetStrike() {
-			m.message = "STRIKE! Wrong password!"
+			m.message = "STRIKE!"
 			m.messageType = "error"
-		} else if result.GetSolved() {
-			m.message = "Module solved!"
-			m.messageType = "success"
 		} else {
 			m.message = ""
 			m.messageType = ""
 		}
+		return
+	}
 
-		return ModuleResultMsg{Result: result}
+	if result.GetStrike() {
+		m.message = "STRIKE! Wrong password!"
+		m.messageType = "error"
+	} else if result.GetSolved() {
+		m.message = "Module solved!"
+		m.messageType = "success"
+	} else {
+		m.message = ""
+		m.messageType = ""
 	}
 }
 
@@ -175,7 +168,7 @@ func (m *PasswordModule) View() string {
 			Height(5)
 
 		if i == m.selectedColumn {
-			boxStyle = boxStyle.BorderForeground(lipgloss.Color("#4ECDC4"))
+			boxStyle = boxStyle.BorderForeground(styles.Colors.Cyan)
 		}
 
 		boxContent := lipgloss.JoinVertical(
@@ -217,7 +210,7 @@ func (m *PasswordModule) View() string {
 	}
 
 	return lipgloss.NewStyle().
-		Width(60).
+		Width(viewWidth(m.width)).
 		Align(lipgloss.Center).
 		Render(content)
 }
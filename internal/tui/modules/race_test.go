@@ -0,0 +1,135 @@
+package modules
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ZaneH/defuse.party-tui/internal/client/clienttest"
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+func raceTestModules() []*pb.Module {
+	return []*pb.Module{
+		{Id: "wires", Type: pb.Module_WIRES, State: &pb.Module_WiresState{WiresState: &pb.WiresState{Wires: []*pb.Wire{
+			{WireColor: pb.Color_RED, Position: 1},
+			{WireColor: pb.Color_BLUE, Position: 2},
+			{WireColor: pb.Color_WHITE, Position: 3},
+		}}}},
+		{Id: "button", Type: pb.Module_BIG_BUTTON, State: &pb.Module_BigButtonState{BigButtonState: &pb.BigButtonState{}}},
+		{Id: "keypad", Type: pb.Module_KEYPAD, State: &pb.Module_KeypadState{KeypadState: &pb.KeypadState{DisplayedSymbols: []pb.Symbol{
+			pb.Symbol_COPYRIGHT, pb.Symbol_FILLEDSTAR, pb.Symbol_HOLLOWSTAR, pb.Symbol_SMILEYFACE,
+		}}}},
+		{Id: "password", Type: pb.Module_PASSWORD, State: &pb.Module_PasswordState{PasswordState: &pb.PasswordState{}}},
+		{Id: "morse", Type: pb.Module_MORSE, State: &pb.Module_MorseState{MorseState: &pb.MorseState{DisplayedPattern: ".-. ..."}}},
+		{Id: "simon", Type: pb.Module_SIMON, State: &pb.Module_SimonState{SimonState: &pb.SimonState{CurrentSequence: []pb.Color{pb.Color_RED, pb.Color_BLUE}}}},
+		{Id: "memory", Type: pb.Module_MEMORY, State: &pb.Module_MemoryState{MemoryState: &pb.MemoryState{DisplayedNumbers: []int32{1, 2, 3, 4}}}},
+		{Id: "whos", Type: pb.Module_WHOS_ON_FIRST, State: &pb.Module_WhosOnFirstState{WhosOnFirstState: &pb.WhosOnFirstState{ButtonWords: []string{"A", "B", "C", "D", "E", "F"}}}},
+		{Id: "maze", Type: pb.Module_MAZE, State: &pb.Module_MazeState{MazeState: &pb.MazeState{}}},
+		{Id: "vent", Type: pb.Module_NEEDY_VENT_GAS, State: &pb.Module_NeedyVentGasState{NeedyVentGasState: &pb.NeedyVentGasState{}}},
+		{Id: "knob", Type: pb.Module_NEEDY_KNOB, State: &pb.Module_NeedyKnobState{NeedyKnobState: &pb.NeedyKnobState{}}},
+	}
+}
+
+var raceTestKeys = []string{
+	"1", "2", "3", "4", "5", "6",
+	"up", "down", "left", "right", "enter", " ",
+	"h", "j", "k", "l", "t", "y", "n", "r", "g", "b",
+}
+
+// runEventLoop mimics the bubbletea runtime: Update and View run on a single
+// goroutine while commands run on their own. Key presses arrive from a
+// separate goroutine without waiting for earlier responses.
+func runEventLoop(t *testing.T, model ModuleModel, presses int) {
+	t.Helper()
+
+	keys := make(chan tea.Msg)
+	results := make(chan tea.Msg, 64)
+
+	// pending is only touched by the loop below, so it needs no locking.
+	pending := 0
+	run := func(cmd tea.Cmd) {
+		if cmd == nil {
+			return
+		}
+		pending++
+		go func() {
+			results <- cmd()
+		}()
+	}
+
+	run(model.Init())
+	run(model.OnEnter())
+
+	go func() {
+		rng := rand.New(rand.NewSource(1))
+		for i := 0; i < presses; i++ {
+			key := raceTestKeys[rng.Intn(len(raceTestKeys))]
+			keys <- tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		}
+		close(keys)
+	}()
+
+	update := func(msg tea.Msg) {
+		_, cmd := model.Update(msg)
+		run(cmd)
+		_ = model.View()
+	}
+
+	for keys != nil || pending > 0 {
+		select {
+		case msg, ok := <-keys:
+			if !ok {
+				keys = nil
+				continue
+			}
+			update(msg)
+		case msg := <-results:
+			pending--
+			switch msg := msg.(type) {
+			case nil:
+			case tea.BatchMsg:
+				for _, cmd := range msg {
+					run(cmd)
+				}
+			case TickMsg:
+				// Animation loops never end on their own; one round is
+				// enough to exercise the tick path.
+			default:
+				update(msg)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("%s: event loop did not drain", model.ID())
+		}
+	}
+	model.OnLeave()
+}
+
+func TestConcurrentInputIsRaceFree(t *testing.T) {
+	var mu sync.Mutex
+	rng := rand.New(rand.NewSource(1))
+
+	// Answer after a short random delay so responses interleave with further
+	// key presses, the way a real network round trip does.
+	client := clienttest.New()
+	client.OnInput = func(input *pb.PlayerInput) (*pb.PlayerInputResult, error) {
+		mu.Lock()
+		delay := time.Duration(rng.Intn(2000)) * time.Microsecond
+		strike := rng.Intn(4) == 0
+		mu.Unlock()
+
+		time.Sleep(delay)
+		return &pb.PlayerInputResult{ModuleId: input.GetModuleId(), Strike: strike}, nil
+	}
+
+	for _, mod := range raceTestModules() {
+		mod := mod
+		t.Run(mod.GetType().String(), func(t *testing.T) {
+			t.Parallel()
+			runEventLoop(t, NewModule(mod, client, "session", "bomb"), 200)
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package modules
 
 import (
-	"context"
 	"math"
 	"time"
 
@@ -36,6 +35,8 @@ type SimonModule struct {
 
 	startTime        time.Time
 	lastFlashedIndex int
+	tickSeq          int
+	animating        bool
 
 	message     string
 	messageType string
@@ -60,22 +61,35 @@ func NewSimonModule(mod *pb.Module, client client.GameClient, sessionID, bombID
 }
 
 func (m *SimonModule) Init() tea.Cmd {
-	return tea.Tick(TICK_INTERVAL, func(t time.Time) tea.Msg {
-		return SimonTickMsg{Time: t}
-	})
+	return nil
 }
 
 type SimonTickMsg struct {
-	Time time.Time
+	ModuleID string
+	Seq      int
+	Time     time.Time
+}
+
+func (msg SimonTickMsg) TargetModuleID() string {
+	return msg.ModuleID
+}
+
+func (m *SimonModule) tick() tea.Cmd {
+	moduleID := m.mod.GetId()
+	seq := m.tickSeq
+	return tea.Tick(TICK_INTERVAL, func(t time.Time) tea.Msg {
+		return SimonTickMsg{ModuleID: moduleID, Seq: seq, Time: t}
+	})
 }
 
 func (m *SimonModule) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case SimonTickMsg:
+		if !m.animating || msg.Seq != m.tickSeq {
+			return m, nil
+		}
 		m.updateAnimation()
-		return m, tea.Tick(TICK_INTERVAL, func(t time.Time) tea.Msg {
-			return SimonTickMsg{Time: t}
-		})
+		return m, m.tick()
 
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -101,6 +115,8 @@ func (m *SimonModule) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case ModuleResultMsg:
+		m.handleResult(msg)
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -142,61 +158,59 @@ func (m *SimonModule) updateAnimation() {
 }
 
 func (m *SimonModule) pressColor(color pb.Color) tea.Cmd {
-	return func() tea.Msg {
-		m.showingSequence = false
-		m.isAnimating = false
-		m.lastFlashedIndex = -1
-
-		input := &pb.PlayerInput{
-			SessionId: m.sessionID,
-			BombId:    m.bombID,
-			ModuleId:  m.mod.GetId(),
-			Input: &pb.PlayerInput_SimonInput{
-				SimonInput: &pb.SimonInput{
-					Color: color,
-				},
+	m.showingSequence = false
+	m.isAnimating = false
+	m.lastFlashedIndex = -1
+
+	return sendInput(m.client, &pb.PlayerInput{
+		SessionId: m.sessionID,
+		BombId:    m.bombID,
+		ModuleId:  m.mod.GetId(),
+		Input: &pb.PlayerInput_SimonInput{
+			SimonInput: &pb.SimonInput{
+				Color: color,
 			},
-		}
+		},
+	})
+}
 
-		result, err := m.client.SendInput(context.Background(), input)
-		if err != nil {
-			return ModuleResultMsg{Err: err}
-		}
+func (m *SimonModule) handleResult(msg ModuleResultMsg) {
+	if msg.Err != nil {
+		return
+	}
 
-		if result.GetSolved() {
-			m.message = "Module solved!"
-			m.messageType = "success"
-			m.showingSequence = false
-		} else if result.GetStrike() {
-			m.message = "STRIKE!"
-			m.messageType = "error"
-			if simonResult := result.GetSimonInputResult(); simonResult != nil {
-				displaySeq := simonResult.GetDisplaySequence()
-				if len(displaySeq) > 0 {
-					m.sequence = displaySeq
-				}
-			}
-			m.showingSequence = true
-			m.startTime = time.Now()
-			m.lastFlashedIndex = -1
-			m.isAnimating = false
-		} else if simonResult := result.GetSimonInputResult(); simonResult != nil {
+	result := msg.Result
+	if result.GetSolved() {
+		m.message = "Module solved!"
+		m.messageType = "success"
+		m.showingSequence = false
+	} else if result.GetStrike() {
+		m.message = "STRIKE!"
+		m.messageType = "error"
+		if simonResult := result.GetSimonInputResult(); simonResult != nil {
 			displaySeq := simonResult.GetDisplaySequence()
 			if len(displaySeq) > 0 {
 				m.sequence = displaySeq
 			}
-
-			if simonResult.GetHasFinishedSeq() {
-				m.showingSequence = true
-				m.startTime = time.Now()
-				m.lastFlashedIndex = -1
-				m.isAnimating = false
-			}
-			m.message = ""
-			m.messageType = ""
+		}
+		m.showingSequence = true
+		m.startTime = time.Now()
+		m.lastFlashedIndex = -1
+		m.isAnimating = false
+	} else if simonResult := result.GetSimonInputResult(); simonResult != nil {
+		displaySeq := simonResult.GetDisplaySequence()
+		if len(displaySeq) > 0 {
+			m.sequence = displaySeq
 		}
 
-		return ModuleResultMsg{Result: result}
+		if simonResult.GetHasFinishedSeq() {
+			m.showingSequence = true
+			m.startTime = time.Now()
+			m.lastFlashedIndex = -1
+			m.isAnimating = false
+		}
+		m.message = ""
+		m.messageType = ""
 	}
 }
 
@@ -261,7 +275,7 @@ func (m *SimonModule) View() string {
 	}
 
 	return lipgloss.NewStyle().
-		Width(60).
+		Width(viewWidth(m.width)).
 		Align(lipgloss.Center).
 		Render(content)
 }
@@ -271,6 +285,7 @@ func (m *SimonModule) renderButton(color pb.Color, label string, size int) strin
 	borderColor := m.getButtonBorderColor(color)
 
 	litBgColor := m.getLitButtonColor(color)
+	border := lipgloss.RoundedBorder()
 
 	if m.showingSequence && len(m.sequence) > 0 {
 		elapsed := time.Since(m.startTime).Seconds()
@@ -284,6 +299,9 @@ func (m *SimonModule) renderButton(color pb.Color, label string, size int) strin
 			if currentIndex < len(m.sequence) && m.sequence[currentIndex] == color && timeInStep < FLASH_DURATION {
 				bgColor = litBgColor
 				borderColor = litBgColor
+				// Mark the flash by more than its color.
+				label = "▶ " + label + " ◀"
+				border = lipgloss.ThickBorder()
 			}
 		}
 	}
@@ -292,8 +310,8 @@ func (m *SimonModule) renderButton(color pb.Color, label string, size int) strin
 		Width(size).
 		Height(size/2).
 		Background(bgColor).
-		Foreground(lipgloss.Color("#000000")).
-		Border(lipgloss.RoundedBorder()).
+		Foreground(styles.Colors.Ink).
+		Border(border).
 		BorderForeground(borderColor).
 		Padding(1, 0).
 		Align(lipgloss.Center).
@@ -305,48 +323,59 @@ func (m *SimonModule) renderButton(color pb.Color, label string, size int) strin
 func (m *SimonModule) getButtonColor(color pb.Color) lipgloss.Color {
 	switch color {
 	case pb.Color_RED:
-		return lipgloss.Color("#8B0000")
+		return styles.Colors.DimRed
 	case pb.Color_BLUE:
-		return lipgloss.Color("#00008B")
+		return styles.Colors.DimBlue
 	case pb.Color_GREEN:
-		return lipgloss.Color("#006400")
+		return styles.Colors.DimGreen
 	case pb.Color_YELLOW:
-		return lipgloss.Color("#8B8B00")
+		return styles.Colors.DimYellow
 	default:
-		return lipgloss.Color("#333333")
+		return styles.Colors.Panel
 	}
 }
 
 func (m *SimonModule) getButtonBorderColor(color pb.Color) lipgloss.Color {
 	switch color {
 	case pb.Color_RED:
-		return lipgloss.Color("#FF6B6B")
+		return styles.Colors.Red
 	case pb.Color_BLUE:
-		return lipgloss.Color("#4DABF7")
+		return styles.Colors.Blue
 	case pb.Color_GREEN:
-		return lipgloss.Color("#6BCB77")
+		return styles.Colors.Green
 	case pb.Color_YELLOW:
-		return lipgloss.Color("#FFD93D")
+		return styles.Colors.Yellow
 	default:
-		return lipgloss.Color("#555555")
+		return styles.Colors.Casing
 	}
 }
 
 func (m *SimonModule) getLitButtonColor(color pb.Color) lipgloss.Color {
 	switch color {
 	case pb.Color_RED:
-		return lipgloss.Color("#FF6B6B")
+		return styles.Colors.Red
 	case pb.Color_BLUE:
-		return lipgloss.Color("#4DABF7")
+		return styles.Colors.Blue
 	case pb.Color_GREEN:
-		return lipgloss.Color("#6BCB77")
+		return styles.Colors.Green
 	case pb.Color_YELLOW:
-		return lipgloss.Color("#FFD93D")
+		return styles.Colors.Yellow
 	default:
-		return lipgloss.Color("#FFFFFF")
+		return styles.Colors.White
 	}
 }
 
+func (m *SimonModule) OnEnter() tea.Cmd {
+	m.tickSeq++
+	m.animating = true
+	m.updateAnimation()
+	return m.tick()
+}
+
+func (m *SimonModule) OnLeave() {
+	m.animating = false
+}
+
 func (m *SimonModule) ID() string {
 	return m.mod.GetId()
 }
@@ -11,6 +11,8 @@ import (
 )
 
 type UnimplementedModule struct {
+	noLifecycle
+
 	mod        *pb.Module
 	width      int
 	height     int
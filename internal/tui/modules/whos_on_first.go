@@ -1,7 +1,6 @@
 package modules
 
 import (
-	"context"
 	"fmt"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,6 +12,8 @@ import (
 )
 
 type WhosOnFirstModule struct {
+	noLifecycle
+
 	mod       *pb.Module
 	client    client.GameClient
 	sessionID string
@@ -50,6 +51,8 @@ func (m *WhosOnFirstModule) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return BackToBombMsg{}
 			}
 		}
+	case ModuleResultMsg:
+		m.handleResult(msg)
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -58,53 +61,49 @@ func (m *WhosOnFirstModule) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *WhosOnFirstModule) pressButton(pos int) tea.Cmd {
-	return func() tea.Msg {
-		state := m.mod.GetWhosOnFirstState()
-		if state == nil {
-			return ModuleResultMsg{Err: fmt.Errorf("no whos on first state")}
-		}
-
-		buttonWords := state.GetButtonWords()
-		if pos < 0 || pos >= len(buttonWords) {
-			return ModuleResultMsg{Err: fmt.Errorf("invalid position")}
-		}
+	state := m.mod.GetWhosOnFirstState()
+	if state == nil {
+		return inputError(m.mod.GetId(), fmt.Errorf("no whos on first state"))
+	}
 
-		word := buttonWords[pos]
+	buttonWords := state.GetButtonWords()
+	if pos < 0 || pos >= len(buttonWords) {
+		return inputError(m.mod.GetId(), fmt.Errorf("invalid position"))
+	}
 
-		input := &pb.PlayerInput{
-			SessionId: m.sessionID,
-			BombId:    m.bombID,
-			ModuleId:  m.mod.GetId(),
-			Input: &pb.PlayerInput_WhosOnFirstInput{
-				WhosOnFirstInput: &pb.WhosOnFirstInput{
-					Word: word,
-				},
+	return sendInput(m.client, &pb.PlayerInput{
+		SessionId: m.sessionID,
+		BombId:    m.bombID,
+		ModuleId:  m.mod.GetId(),
+		Input: &pb.PlayerInput_WhosOnFirstInput{
+			WhosOnFirstInput: &pb.WhosOnFirstInput{
+				Word: buttonWords[pos],
 			},
-		}
-
-		result, err := m.client.SendInput(context.Background(), input)
-		if err != nil {
-			return ModuleResultMsg{Err: err}
-		}
+		},
+	})
+}
 
-		if wofResult := result.GetWhosOnFirstInputResult(); wofResult != nil {
-			if wofState := wofResult.GetWhosOnFirstState(); wofState != nil {
-				m.mod.State = &pb.Module_WhosOnFirstState{WhosOnFirstState: wofState}
-			}
-		}
+func (m *WhosOnFirstModule) handleResult(msg ModuleResultMsg) {
+	if msg.Err != nil {
+		return
+	}
 
-		if result.GetStrike() {
-			m.message = "STRIKE!"
-			m.messageType = "error"
-		} else if result.GetSolved() {
-			m.message = "Module solved!"
-			m.messageType = "success"
-		} else {
-			m.message = ""
-			m.messageType = ""
+	result := msg.Result
+	if wofResult := result.GetWhosOnFirstInputResult(); wofResult != nil {
+		if wofState := wofResult.GetWhosOnFirstState(); wofState != nil {
+			m.mod.State = &pb.Module_WhosOnFirstState{WhosOnFirstState: wofState}
 		}
+	}
 
-		return ModuleResultMsg{Result: result}
+	if result.GetStrike() {
+		m.message = "STRIKE!"
+		m.messageType = "error"
+	} else if result.GetSolved() {
+		m.message = "Module solved!"
+		m.messageType = "success"
+	} else {
+		m.message = ""
+		m.messageType = ""
 	}
 }
 
@@ -120,7 +119,7 @@ func (m *WhosOnFirstModule) View() string {
 
 	screenStyle := lipgloss.NewStyle().
 		Border(lipgloss.DoubleBorder()).
-		BorderForeground(lipgloss.Color("#FFD93D")).
+		BorderForeground(styles.Colors.Yellow).
 		Padding(1, 2).
 		Width(14).
 		Height(3)
@@ -182,7 +181,7 @@ func (m *WhosOnFirstModule) View() string {
 	)
 
 	content := lipgloss.NewStyle().
-		Width(60).
+		Width(viewWidth(m.width)).
 		Align(lipgloss.Center).
 		Render(
 			lipgloss.JoinVertical(
@@ -196,7 +195,7 @@ func (m *WhosOnFirstModule) View() string {
 	if m.message != "" {
 		if m.messageType == "error" {
 			content = lipgloss.NewStyle().
-				Width(60).
+				Width(viewWidth(m.width)).
 				Align(lipgloss.Center).
 				Render(
 					lipgloss.JoinVertical(
@@ -207,7 +206,7 @@ func (m *WhosOnFirstModule) View() string {
 				)
 		} else if m.messageType == "success" {
 			content = lipgloss.NewStyle().
-				Width(60).
+				Width(viewWidth(m.width)).
 				Align(lipgloss.Center).
 				Render(
 					lipgloss.JoinVertical(
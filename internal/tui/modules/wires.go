@@ -1,8 +1,8 @@
 package modules
 
 import (
-	"context"
 	"fmt"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -12,14 +12,9 @@ import (
 	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
 )
 
-type ModuleResultMsg struct {
-	Result *pb.PlayerInputResult
-	Err    error
-}
-
-type BackToBombMsg struct{}
-
 type WiresModule struct {
+	noLifecycle
+
 	mod       *pb.Module
 	client    client.GameClient
 	sessionID string
@@ -60,6 +55,8 @@ func (m *WiresModule) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return BackToBombMsg{}
 			}
 		}
+	case ModuleResultMsg:
+		m.handleResult(msg)
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -68,55 +65,52 @@ func (m *WiresModule) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *WiresModule) cutWire(position int32) tea.Cmd {
-	return func() tea.Msg {
-		state := m.mod.GetWiresState()
-		if state == nil {
-			return ModuleResultMsg{Err: fmt.Errorf("no wires state")}
-		}
-
-		wires := state.GetWires()
-		wireExists := false
-		for _, wire := range wires {
-			if wire.GetPosition() == position {
-				wireExists = true
-				break
-			}
-		}
+	state := m.mod.GetWiresState()
+	if state == nil {
+		return inputError(m.mod.GetId(), fmt.Errorf("no wires state"))
+	}
 
-		if !wireExists {
-			return ModuleResultMsg{Err: fmt.Errorf("no wire at position %d", position)}
+	wireExists := false
+	for _, wire := range state.GetWires() {
+		if wire.GetPosition() == position {
+			wireExists = true
+			break
 		}
+	}
 
-		if m.cutWires[position] {
-			return ModuleResultMsg{Err: fmt.Errorf("wire already cut")}
-		}
+	if !wireExists {
+		return inputError(m.mod.GetId(), fmt.Errorf("no wire at position %d", position))
+	}
 
-		input := &pb.PlayerInput{
-			SessionId: m.sessionID,
-			BombId:    m.bombID,
-			ModuleId:  m.mod.GetId(),
-			Input:     &pb.PlayerInput_WiresInput{WiresInput: &pb.WiresInput{WirePosition: position}},
-		}
+	if m.cutWires[position] {
+		return inputError(m.mod.GetId(), fmt.Errorf("wire already cut"))
+	}
 
-		result, err := m.client.SendInput(context.Background(), input)
-		if err != nil {
-			return ModuleResultMsg{Err: err}
-		}
+	return sendInput(m.client, &pb.PlayerInput{
+		SessionId: m.sessionID,
+		BombId:    m.bombID,
+		ModuleId:  m.mod.GetId(),
+		Input:     &pb.PlayerInput_WiresInput{WiresInput: &pb.WiresInput{WirePosition: position}},
+	})
+}
 
-		m.cutWires[position] = true
-
-		if result.GetStrike() {
-			m.message = "STRIKE! Wrong wire!"
-			m.messageType = "error"
-		} else if result.GetSolved() {
-			m.message = "Module solved!"
-			m.messageType = "success"
-		} else {
-			m.message = ""
-			m.messageType = ""
-		}
+func (m *WiresModule) handleResult(msg ModuleResultMsg) {
+	if msg.Err != nil {
+		return
+	}
 
-		return ModuleResultMsg{Result: result}
+	m.cutWires[msg.Input.GetWiresInput().GetWirePosition()] = true
+
+	result := msg.Result
+	if result.GetStrike() {
+		m.message = "STRIKE! Wrong wire!"
+		m.messageType = "error"
+	} else if result.GetSolved() {
+		m.message = "Module solved!"
+		m.messageType = "success"
+	} else {
+		m.message = ""
+		m.messageType = ""
 	}
 }
 
@@ -150,7 +144,8 @@ func (m *WiresModule) View() string {
 
 		isCut := m.cutWires[pos] || wire.GetIsCut()
 
-		wireDisplay := colorStyle.Render("▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓")
+		// The wire is lettered with its color so it never reads by hue alone.
+		wireDisplay := colorStyle.Render(strings.Repeat("▓▓▓"+colorLetter(wire.GetWireColor()), 5))
 		if isCut {
 			wireDisplay = styles.Help.Render("────────────────────")
 		}
@@ -188,7 +183,7 @@ func (m *WiresModule) View() string {
 	}
 
 	return lipgloss.NewStyle().
-		Width(60).
+		Width(viewWidth(m.width)).
 		Align(lipgloss.Center).
 		Render(content)
 }
@@ -238,6 +233,19 @@ func colorToString(c pb.Color) string {
 	}
 }
 
+// colorLetter abbreviates a color to one letter, K standing for black as it
+// does in resistor codes so as not to clash with blue.
+func colorLetter(c pb.Color) string {
+	switch c {
+	case pb.Color_BLACK:
+		return "K"
+	case pb.Color_UNKNOWN:
+		return "?"
+	default:
+		return colorToString(c)[:1]
+	}
+}
+
 func colorToStyle(c pb.Color) lipgloss.Style {
 	switch c {
 	case pb.Color_RED:
@@ -252,6 +260,10 @@ func colorToStyle(c pb.Color) lipgloss.Style {
 		return styles.Yellow
 	case pb.Color_GREEN:
 		return styles.Green
+	case pb.Color_ORANGE:
+		return styles.Orange
+	case pb.Color_PINK:
+		return styles.Pink
 	default:
 		return styles.Normal
 	}
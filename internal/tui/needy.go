@@ -0,0 +1,139 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ZaneH/defuse.party-tui/internal/styles"
+	"github.com/ZaneH/defuse.party-tui/internal/tui/modules"
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+const needyUrgentThreshold = 10 * time.Second
+
+type needyAlert struct {
+	bombIndex int
+	mod       *pb.Module
+	remaining time.Duration
+}
+
+func (a needyAlert) urgent() bool {
+	return a.remaining < needyUrgentThreshold
+}
+
+// needyRemaining prefers the live module model, which is updated by player
+// input, and falls back to the last state fetched from the backend.
+func (m *Model) needyRemaining(mod *pb.Module, now time.Time) (time.Duration, bool) {
+	if cached, ok := m.moduleCache[mod.GetId()].(modules.NeedyModule); ok {
+		return cached.Remaining()
+	}
+
+	var startedAt int64
+	var duration int32
+	switch mod.GetType() {
+	case pb.Module_NEEDY_VENT_GAS:
+		startedAt = mod.GetNeedyVentGasState().GetCountdownStartedAt()
+		duration = mod.GetNeedyVentGasState().GetCountdownDuration()
+	case pb.Module_NEEDY_KNOB:
+		startedAt = mod.GetNeedyKnobState().GetCountdownStartedAt()
+		duration = mod.GetNeedyKnobState().GetCountdownDuration()
+	default:
+		return 0, false
+	}
+	if startedAt == 0 {
+		return 0, false
+	}
+
+	remaining := time.Duration(duration)*time.Second - now.Sub(time.Unix(startedAt, 0))
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining, true
+}
+
+// needyAlerts lists every running needy countdown, most urgent first.
+func (m *Model) needyAlerts(now time.Time) []needyAlert {
+	var alerts []needyAlert
+	for i, bomb := range m.bombs {
+		for _, mod := range bomb.GetModules() {
+			if !isNeedyModule(mod.GetType()) {
+				continue
+			}
+			if remaining, active := m.needyRemaining(mod, now); active {
+				alerts = append(alerts, needyAlert{bombIndex: i, mod: mod, remaining: remaining})
+			}
+		}
+	}
+
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].remaining != alerts[j].remaining {
+			return alerts[i].remaining < alerts[j].remaining
+		}
+		return alerts[i].mod.GetId() < alerts[j].mod.GetId()
+	})
+	return alerts
+}
+
+func (m *Model) renderNeedyAlerts(now time.Time) string {
+	alerts := m.needyAlerts(now)
+	if len(alerts) == 0 {
+		return ""
+	}
+
+	blinkOn := now.Unix()%2 == 0
+
+	parts := []string{styles.Warning.Bold(true).Render("NEEDY:")}
+	for _, alert := range alerts {
+		label := fmt.Sprintf("%s %s", m.moduleTypeName(alert.mod.GetType()), formatTimer(alert.remaining))
+		if len(m.bombs) > 1 {
+			label = fmt.Sprintf("B%d %s", alert.bombIndex+1, label)
+		}
+
+		style := styles.Warning
+		if alert.urgent() {
+			style = styles.Error.Bold(true)
+			if blinkOn {
+				style = styles.Strike
+			}
+		}
+		if m.activeModule != nil && m.activeModule.ID() == alert.mod.GetId() {
+			style = style.Underline(true)
+		}
+		parts = append(parts, " ", style.Render(label))
+	}
+	parts = append(parts, "  ", styles.Help.Render("[!] Jump"))
+
+	return lipgloss.JoinHorizontal(lipgloss.Left, parts...)
+}
+
+// jumpToUrgentNeedy opens the needy module with the least time left, flipping
+// to its bomb and face so that ESC returns somewhere sensible.
+func (m *Model) jumpToUrgentNeedy() tea.Cmd {
+	alerts := m.needyAlerts(m.now())
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	target := alerts[0]
+	if m.activeModule != nil && m.activeModule.ID() == target.mod.GetId() {
+		return nil
+	}
+
+	m.selectedBomb = target.bombIndex
+	if pos := target.mod.GetPosition(); pos != nil {
+		m.currentFace = int(pos.GetFace())
+	}
+	m.selectedModule = 0
+	for i, mod := range m.getCurrentFaceModules() {
+		if mod.GetId() == target.mod.GetId() {
+			m.selectedModule = i
+			break
+		}
+	}
+
+	return m.openModule(target.mod)
+}
@@ -0,0 +1,245 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ZaneH/defuse.party-tui/internal/profile"
+	"github.com/ZaneH/defuse.party-tui/internal/styles"
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+// attachProfile ties the session to a stored player. Players without a
+// nickname are asked for one before reaching the main menu.
+func (m *Model) attachProfile(store *profile.Store, p *profile.Profile) {
+	m.profiles = store
+	m.profile = p
+	if p != nil {
+		if theme, ok := styles.ThemeByID(p.Prefs.Theme); ok {
+			m.theme = theme
+		}
+	}
+	if store != nil && p != nil && p.IsNew() {
+		m.state = StateNickname
+		m.nicknameInput = ""
+		m.nicknameErr = nil
+	}
+}
+
+// namedKeys are the keys a keybinding can name besides single characters.
+var namedKeys = map[string]tea.KeyType{
+	"enter":     tea.KeyEnter,
+	"esc":       tea.KeyEsc,
+	"tab":       tea.KeyTab,
+	"backspace": tea.KeyBackspace,
+	"up":        tea.KeyUp,
+	"down":      tea.KeyDown,
+	"left":      tea.KeyLeft,
+	"right":     tea.KeyRight,
+	"pgup":      tea.KeyPgUp,
+	"pgdown":    tea.KeyPgDown,
+	" ":         tea.KeySpace,
+}
+
+// boundKey swaps the key the player pressed for the one their keybindings
+// put in its place. Nicknames and manual searches are typed as pressed.
+func (m *Model) boundKey(msg tea.KeyMsg) tea.KeyMsg {
+	if m.profile == nil || m.state == StateNickname || m.manual.searching {
+		return msg
+	}
+	bound, ok := m.profile.Prefs.Keybindings[msg.String()]
+	if !ok {
+		return msg
+	}
+	if keyType, ok := namedKeys[bound]; ok {
+		return tea.KeyMsg{Type: keyType}
+	}
+	if runes := []rune(bound); len(runes) == 1 {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: runes}
+	}
+	return msg
+}
+
+func (m *Model) handleNicknameKeys(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return tea.Quit
+	case tea.KeyEsc:
+		// Skipping keeps the profile nameless, so the prompt returns next time.
+		m.state = StateMainMenu
+		m.nicknameErr = nil
+	case tea.KeyBackspace:
+		if runes := []rune(m.nicknameInput); len(runes) > 0 {
+			m.nicknameInput = string(runes[:len(runes)-1])
+		}
+		m.nicknameErr = nil
+	case tea.KeyEnter:
+		name, err := profile.ValidateNickname(m.nicknameInput)
+		if err != nil {
+			m.nicknameErr = err
+			return nil
+		}
+		return m.saveNickname(name)
+	case tea.KeySpace, tea.KeyRunes:
+		for _, r := range msg.Runes {
+			if unicode.IsPrint(r) && len([]rune(m.nicknameInput)) < profile.MaxNicknameLength {
+				m.nicknameInput += string(r)
+			}
+		}
+		m.nicknameErr = nil
+	}
+	return nil
+}
+
+func (m *Model) saveNickname(name string) tea.Cmd {
+	store, id := m.profiles, m.profile.ID
+	return func() tea.Msg {
+		p, err := store.SetNickname(id, name)
+		return nicknameSavedMsg{profile: p, err: err}
+	}
+}
+
+func (m *Model) handleNicknameSaved(msg nicknameSavedMsg) tea.Cmd {
+	if msg.err != nil {
+		m.nicknameErr = msg.err
+		return nil
+	}
+	m.profile = msg.profile
+	m.state = StateMainMenu
+	m.menuSelection = 0
+	return m.notify(toastInfo, fmt.Sprintf("Welcome, %s!", m.profile.Nickname))
+}
+
+// recordGame saves the game that just ended to the player's history.
+func (m *Model) recordGame() tea.Cmd {
+	if m.profiles == nil || m.profile == nil {
+		return nil
+	}
+
+	strikes, maxStrikes := m.totalStrikes()
+	record := profile.GameRecord{
+		PlayedAt:   m.startedAt,
+		Mode:       gameMode(m.pendingGameConfig),
+		Mission:    m.gameMission(m.pendingGameConfig),
+		Outcome:    m.gameOutcome(),
+		Duration:   m.endedAt.Sub(m.startedAt),
+		Strikes:    int(strikes),
+		MaxStrikes: int(maxStrikes),
+	}
+	for _, bomb := range m.bombs {
+		for _, mod := range bomb.GetModules() {
+			if !requiresSolve(mod.GetType()) {
+				continue
+			}
+			record.Modules++
+			if mod.GetSolved() {
+				record.Solved++
+			}
+		}
+	}
+
+	store, id := m.profiles, m.profile.ID
+	return func() tea.Msg {
+		p, err := store.RecordGame(id, record)
+		return profileUpdatedMsg{profile: p, err: err, failure: "Couldn't save this game to your history"}
+	}
+}
+
+// setCampaign turns campaign mode on or off for the player.
+func (m *Model) setCampaign(on bool) tea.Cmd {
+	if m.profiles == nil || m.profile == nil {
+		return nil
+	}
+	store, id := m.profiles, m.profile.ID
+	// Apply the toggle straight away rather than waiting on the write.
+	m.profile.Prefs.Campaign = on
+	return func() tea.Msg {
+		p, err := store.SetCampaign(id, on)
+		return profileUpdatedMsg{profile: p, err: err, failure: "Couldn't save campaign mode"}
+	}
+}
+
+// setTheme switches the session to theme, saving it as the player's choice
+// if they have a profile.
+func (m *Model) setTheme(theme styles.Theme) tea.Cmd {
+	m.theme = theme
+	if m.profiles == nil || m.profile == nil {
+		return nil
+	}
+	store, id := m.profiles, m.profile.ID
+	m.profile.Prefs.Theme = theme.ID
+	return func() tea.Msg {
+		p, err := store.SetTheme(id, theme.ID)
+		return profileUpdatedMsg{profile: p, err: err, failure: "Couldn't save your theme"}
+	}
+}
+
+func (m *Model) handleProfileUpdated(msg profileUpdatedMsg) tea.Cmd {
+	if msg.err != nil {
+		return m.notify(toastWarning, msg.failure)
+	}
+	m.profile = msg.profile
+	return nil
+}
+
+func gameMode(config *pb.GameConfig) string {
+	switch {
+	case strings.HasPrefix(config.GetSeed(), dailySeedPrefix):
+		return "daily"
+	case config.GetLevel() != nil:
+		return fmt.Sprintf("level %d", config.GetLevel().GetLevel())
+	case config.GetPreset() != nil:
+		return strings.ToLower(config.GetPreset().GetMission().String())
+	case config.GetCustom() != nil:
+		return "custom"
+	}
+	return "unknown"
+}
+
+// gameMission finds the mission being played, if any. Catalog missions send
+// their own CustomBombConfig, so they are recognised by identity; a bomb built
+// in Free Play never matches.
+func (m *Model) gameMission(config *pb.GameConfig) string {
+	if preset := config.GetPreset(); preset != nil {
+		return preset.GetMission().String()
+	}
+	if custom := config.GetCustom(); custom != nil {
+		for _, section := range m.missionSections {
+			for _, mission := range section.Missions {
+				if mission.Custom == custom {
+					return mission.key()
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func (m *Model) nicknameView() string {
+	field := styles.Active.Render(fmt.Sprintf("%-*s", profile.MaxNicknameLength+1, m.nicknameInput+"_"))
+
+	status := styles.Help.Render(fmt.Sprintf("%d / %d", len([]rune(m.nicknameInput)), profile.MaxNicknameLength))
+	if m.nicknameErr != nil {
+		status = styles.Error.Render(m.nicknameErr.Error())
+	}
+
+	return styles.Center(
+		lipgloss.JoinVertical(
+			lipgloss.Center,
+			styles.Title.Render("DEFUSE.PARTY"),
+			"",
+			styles.Subtitle.Render("Welcome, new recruit. What should we call you?"),
+			"",
+			"> "+field,
+			"",
+			status,
+			"",
+			styles.Help.Render("[ENTER] Save  [ESC] Skip"),
+		),
+		m.width, m.height,
+	)
+}
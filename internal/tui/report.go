@@ -0,0 +1,307 @@
+package tui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/ZaneH/defuse.party-tui/internal/styles"
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+type moduleStats struct {
+	moduleID   string
+	moduleType pb.Module_ModuleType
+	bombIndex  int
+
+	firstOpened time.Time
+	activeFor   time.Duration
+	strikes     int
+	solvedAt    time.Time
+}
+
+// gameStats collects per-module timing for the post-game report. All methods
+// are safe to call on a nil receiver so callers don't need to guard against
+// inputs arriving outside of a game.
+type gameStats struct {
+	startedAt time.Time
+	modules   map[string]*moduleStats
+
+	activeID    string
+	activeSince time.Time
+}
+
+func newGameStats(startedAt time.Time) *gameStats {
+	return &gameStats{
+		startedAt: startedAt,
+		modules:   make(map[string]*moduleStats),
+	}
+}
+
+func (s *gameStats) module(moduleID string) *moduleStats {
+	stats, exists := s.modules[moduleID]
+	if !exists {
+		stats = &moduleStats{moduleID: moduleID, bombIndex: -1}
+		s.modules[moduleID] = stats
+	}
+	return stats
+}
+
+func (s *gameStats) moduleOpened(bombIndex int, mod *pb.Module, now time.Time) {
+	if s == nil {
+		return
+	}
+	s.moduleClosed(now)
+
+	stats := s.module(mod.GetId())
+	stats.moduleType = mod.GetType()
+	stats.bombIndex = bombIndex
+	if stats.firstOpened.IsZero() {
+		stats.firstOpened = now
+	}
+	s.activeID = mod.GetId()
+	s.activeSince = now
+}
+
+func (s *gameStats) moduleClosed(now time.Time) {
+	if s == nil || s.activeID == "" {
+		return
+	}
+	s.module(s.activeID).activeFor += now.Sub(s.activeSince)
+	s.activeID = ""
+	s.activeSince = time.Time{}
+}
+
+func (s *gameStats) strike(moduleID string) {
+	if s == nil || moduleID == "" {
+		return
+	}
+	s.module(moduleID).strikes++
+}
+
+func (s *gameStats) solved(moduleID string, now time.Time) {
+	if s == nil {
+		return
+	}
+	stats := s.module(moduleID)
+	if stats.solvedAt.IsZero() {
+		stats.solvedAt = now
+	}
+}
+
+func (s *gameStats) sorted() []*moduleStats {
+	if s == nil {
+		return nil
+	}
+	var result []*moduleStats
+	for _, stats := range s.modules {
+		result = append(result, stats)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.firstOpened.IsZero() != b.firstOpened.IsZero() {
+			return !a.firstOpened.IsZero()
+		}
+		if !a.firstOpened.Equal(b.firstOpened) {
+			return a.firstOpened.Before(b.firstOpened)
+		}
+		return a.moduleID < b.moduleID
+	})
+	return result
+}
+
+type GameReport struct {
+	SessionID            string         `json:"session_id"`
+	Outcome              string         `json:"outcome"`
+	DurationSeconds      float64        `json:"duration_seconds"`
+	TimeRemainingSeconds float64        `json:"time_remaining_seconds"`
+	Strikes              int32          `json:"strikes"`
+	MaxStrikes           int32          `json:"max_strikes"`
+	Modules              []ModuleReport `json:"modules"`
+}
+
+type ModuleReport struct {
+	ModuleID           string   `json:"module_id"`
+	Type               string   `json:"type"`
+	Bomb               int      `json:"bomb"`
+	FirstOpenedSeconds *float64 `json:"first_opened_seconds,omitempty"`
+	ActiveSeconds      float64  `json:"active_seconds"`
+	Strikes            int      `json:"strikes"`
+	Solved             bool     `json:"solved"`
+	SolvedAtSeconds    *float64 `json:"solved_at_seconds,omitempty"`
+}
+
+func (m *Model) gameOutcome() string {
+	switch {
+	case m.err == nil:
+		return "defused"
+	case errors.Is(m.err, errExploded):
+		return "exploded"
+	case errors.Is(m.err, errTimeUp):
+		return "timeout"
+	default:
+		return "error"
+	}
+}
+
+func (m *Model) buildReport() GameReport {
+	strikes, maxStrikes := m.totalStrikes()
+	report := GameReport{
+		SessionID:            m.sessionID,
+		Outcome:              m.gameOutcome(),
+		TimeRemainingSeconds: m.timeRemaining().Seconds(),
+		Strikes:              strikes,
+		MaxStrikes:           maxStrikes,
+		Modules:              []ModuleReport{},
+	}
+	if !m.startedAt.IsZero() && !m.endedAt.IsZero() {
+		report.DurationSeconds = m.endedAt.Sub(m.startedAt).Seconds()
+	}
+
+	for _, stats := range m.stats.sorted() {
+		mod := ModuleReport{
+			ModuleID:      stats.moduleID,
+			Type:          stats.moduleType.String(),
+			Bomb:          stats.bombIndex + 1,
+			ActiveSeconds: stats.activeFor.Seconds(),
+			Strikes:       stats.strikes,
+			Solved:        !stats.solvedAt.IsZero(),
+		}
+		if !stats.firstOpened.IsZero() {
+			opened := stats.firstOpened.Sub(m.startedAt).Seconds()
+			mod.FirstOpenedSeconds = &opened
+		}
+		if mod.Solved {
+			solvedAt := stats.solvedAt.Sub(m.startedAt).Seconds()
+			mod.SolvedAtSeconds = &solvedAt
+		}
+		report.Modules = append(report.Modules, mod)
+	}
+
+	return report
+}
+
+func (m *Model) reportJSON() string {
+	data, err := json.MarshalIndent(m.buildReport(), "", "  ")
+	if err != nil {
+		return fmt.Sprintf("failed to encode report: %v", err)
+	}
+	return string(data)
+}
+
+func (m *Model) reportTableView() string {
+	rows := m.stats.sorted()
+	if len(rows) == 0 {
+		return styles.Help.Render("No modules were opened.")
+	}
+
+	lines := []string{
+		styles.Subtitle.Render(fmt.Sprintf("%-16s %7s %7s %7s %7s", "MODULE", "OPENED", "ACTIVE", "STRIKES", "SOLVED")),
+	}
+	for _, stats := range rows {
+		opened := "-"
+		if !stats.firstOpened.IsZero() {
+			opened = formatTimer(stats.firstOpened.Sub(m.startedAt))
+		}
+		solved := "-"
+		if !stats.solvedAt.IsZero() {
+			solved = formatTimer(stats.solvedAt.Sub(m.startedAt))
+		}
+
+		line := fmt.Sprintf("%-16s %7s %7s %7d %7s",
+			m.moduleTypeName(stats.moduleType),
+			opened,
+			formatTimer(stats.activeFor),
+			stats.strikes,
+			solved,
+		)
+		if stats.strikes > 0 {
+			line = styles.Warning.Render(line)
+		}
+		lines = append(lines, line)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+const reportJSONHint = "[↑/↓] Scroll  [C] Copy  [E] Hide JSON  [ESC] Back"
+
+// reportJSONRows is how many lines of the JSON report fit on screen, or 0 if
+// all of them do.
+func (m *Model) reportJSONRows() int {
+	layout := m.layout()
+	rows := layout.BodyRows(m.reportHeader(), layout.Hint(reportJSONHint))
+	if rows == 0 {
+		return 0
+	}
+	// The title and the line below it.
+	return max(rows-2, 1)
+}
+
+func (m *Model) reportHeader() string {
+	return m.layout().Header().Render(styles.Title.Render("DEFUSE.PARTY"))
+}
+
+func (m *Model) scrollReport(delta int) {
+	rows := m.reportJSONRows()
+	if rows == 0 {
+		return
+	}
+	lines := strings.Count(strings.TrimSpace(m.reportJSON()), "\n") + 1
+	m.reportScroll = min(max(m.reportScroll+delta, 0), max(lines-rows, 0))
+}
+
+// copyReport sends the JSON report to the player's clipboard.
+func (m *Model) copyReport() tea.Cmd {
+	report, clipboard := m.reportJSON(), m.clipboard
+	return func() tea.Msg {
+		if clipboard == nil {
+			return reportCopiedMsg{err: errors.New("no clipboard for this session")}
+		}
+		return reportCopiedMsg{err: clipboard(report)}
+	}
+}
+
+func (m *Model) handleReportCopied(msg reportCopiedMsg) tea.Cmd {
+	if msg.err != nil {
+		return m.notify(toastWarning, "Couldn't copy the report: "+msg.err.Error())
+	}
+	return m.notify(toastInfo, "Copied the JSON report to the clipboard")
+}
+
+func (m *Model) reportJSONView() string {
+	layout := m.layout()
+	lines := strings.Split(strings.TrimSpace(m.reportJSON()), "\n")
+
+	// Long lines are cut rather than wrapped so the page keeps its height;
+	// copying the report gets them in full.
+	start, end := 0, len(lines)
+	if rows := m.reportJSONRows(); rows > 0 && rows < len(lines) {
+		start = min(m.reportScroll, len(lines)-rows)
+		end = start + rows
+	}
+	body := make([]string, 0, end-start)
+	for _, line := range lines[start:end] {
+		body = append(body, ansi.Truncate(line, layout.TextWidth(), "…"))
+	}
+
+	// The position goes under the title, where it fits on narrow terminals.
+	position := ""
+	if end-start < len(lines) {
+		position = styles.Help.Render(fmt.Sprintf("lines %d-%d of %d", start+1, end, len(lines)))
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		m.reportHeader(),
+		layout.Content().Render(lipgloss.JoinVertical(lipgloss.Left, append([]string{styles.Title.Render("GAME REPORT (JSON)"), position}, body...)...)),
+		layout.Hint(reportJSONHint),
+	)
+}
@@ -4,11 +4,15 @@ type AppState int
 
 const (
 	StateMainMenu AppState = iota
+	StateNickname
 	StateSectionSelect
 	StateMissionSelect
+	StateMissionBriefing
 	StateFreePlayMenu
 	StateFreePlayAdvanced
+	StateDaily
 	StateLoading
+	StateLoadError
 	StateBombSelection
 	StateBombView
 	StateModuleActive
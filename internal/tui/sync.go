@@ -0,0 +1,167 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ZaneH/defuse.party-tui/internal/client"
+	"github.com/ZaneH/defuse.party-tui/internal/styles"
+	"github.com/ZaneH/defuse.party-tui/internal/tui/modules"
+	pb "github.com/ZaneH/defuse.party-go/pkg/proto"
+)
+
+const maxResyncBackoff = 30 * time.Second
+
+// scheduleResync queues the next GetBombs poll. Failed polls back off
+// exponentially so a struggling backend isn't hammered, but the loop itself
+// only stops when the game ends.
+func (m *Model) scheduleResync() tea.Cmd {
+	interval := m.config.ResyncInterval
+	if interval <= 0 || m.gameClient == nil {
+		return nil
+	}
+
+	delay := interval
+	for i := 0; i < m.syncFailures && delay < maxResyncBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxResyncBackoff {
+		delay = maxResyncBackoff
+	}
+
+	gameClient := m.gameClient
+	sessionID := m.sessionID
+	gameID := m.gameID
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		bombs, err := gameClient.GetBombs(context.Background(), sessionID)
+		return bombsSyncedMsg{gameID: gameID, bombs: bombs, err: err}
+	})
+}
+
+func (m *Model) handleBombsSynced(msg bombsSyncedMsg) tea.Cmd {
+	m.refreshBackendStatus()
+	if msg.err != nil {
+		m.syncErr = msg.err
+		m.syncFailures++
+		return m.scheduleResync()
+	}
+
+	m.syncErr = nil
+	m.syncFailures = 0
+	m.lastSyncedAt = m.now()
+	m.mergeBombs(msg.bombs)
+
+	for _, bomb := range m.bombs {
+		if bomb.GetMaxStrikes() > 0 && bomb.GetStrikeCount() >= bomb.GetMaxStrikes() {
+			return m.endGame(errExploded)
+		}
+	}
+	if m.allBombsDefused() {
+		return m.endGame(nil)
+	}
+
+	return m.scheduleResync()
+}
+
+// refreshBackendStatus copies the game client's view of backend health, if it
+// keeps one, so the header can warn about a flaky backend.
+func (m *Model) refreshBackendStatus() {
+	if reporter, ok := m.gameClient.(client.StatusReporter); ok {
+		m.backendStatus = reporter.Status()
+	}
+}
+
+// renderBackendStatus explains why inputs may not be going through, or
+// returns "" when the backend looks healthy.
+func (m *Model) renderBackendStatus(now time.Time) string {
+	status := m.backendStatus
+	switch {
+	case status.State == client.BreakerOpen:
+		wait := status.RetryAt.Sub(now).Round(time.Second)
+		if wait < time.Second {
+			wait = time.Second
+		}
+		return styles.Warning.Render(fmt.Sprintf("Backend unreachable, inputs paused. Retrying in %s...", wait))
+	case status.State == client.BreakerHalfOpen:
+		return styles.Warning.Render("Reconnecting to the backend...")
+	case status.Failures > 0:
+		return styles.Warning.Render(fmt.Sprintf("Backend is struggling (%d failed requests)", status.Failures))
+	}
+	return ""
+}
+
+// mergeBombs folds freshly fetched bomb state into the cached bombs in place.
+// Cached module models share their *pb.Module with m.bombs, so updating the
+// existing message keeps them in sync; UpdateState is called as well for
+// modules that copy state out of the message.
+func (m *Model) mergeBombs(fresh []*pb.Bomb) {
+	for _, freshBomb := range fresh {
+		bomb := m.bombByID(freshBomb.GetId())
+		if bomb == nil {
+			continue
+		}
+
+		if freshBomb.GetStrikeCount() != bomb.GetStrikeCount() {
+			m.setStrikes(bomb, freshBomb.GetStrikeCount())
+		}
+
+		for _, freshMod := range freshBomb.GetModules() {
+			mod := findBombModule(bomb, freshMod.GetId())
+			if mod == nil || proto.Equal(mod, freshMod) {
+				continue
+			}
+
+			if freshMod.GetSolved() && !mod.GetSolved() {
+				m.markModuleSolved(mod.GetId())
+			}
+			mod.Solved = mod.GetSolved() || freshMod.GetSolved()
+			if freshMod.GetState() != nil {
+				mod.State = freshMod.State
+			}
+
+			if cached, exists := m.moduleCache[mod.GetId()]; exists {
+				cached.UpdateState(mod)
+			}
+		}
+	}
+}
+
+func (m *Model) bombByID(bombID string) *pb.Bomb {
+	for _, bomb := range m.bombs {
+		if bomb.GetId() == bombID {
+			return bomb
+		}
+	}
+	return nil
+}
+
+func findBombModule(bomb *pb.Bomb, moduleID string) *pb.Module {
+	if mod, exists := bomb.GetModules()[moduleID]; exists && mod.GetId() == moduleID {
+		return mod
+	}
+	for _, mod := range bomb.GetModules() {
+		if mod.GetId() == moduleID {
+			return mod
+		}
+	}
+	return nil
+}
+
+// setStrikes records bomb's strike count and shows it on the bomb's own
+// clock. Every bomb has a clock, each counting its own strikes.
+func (m *Model) setStrikes(bomb *pb.Bomb, strikes int32) {
+	bomb.StrikeCount = strikes
+
+	for _, mod := range bomb.GetModules() {
+		if mod.GetType() != pb.Module_CLOCK {
+			continue
+		}
+		if clockMod, ok := m.moduleCache[mod.GetId()].(*modules.ClockModule); ok {
+			clockMod.UpdateStrikes(strikes)
+		}
+	}
+}
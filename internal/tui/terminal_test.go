@@ -0,0 +1,30 @@
+package tui
+
+import (
+	"testing"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/termenv"
+
+	"github.com/ZaneH/defuse.party-tui/internal/client/clienttest"
+	"github.com/ZaneH/defuse.party-tui/internal/styles"
+	"github.com/ZaneH/defuse.party-tui/internal/tui/tuitest"
+)
+
+func TestASCIITerminal(t *testing.T) {
+	m := newTestModel(clienttest.New(testBomb()))
+	m.terminal = styles.Terminal{Profile: termenv.Ascii, ASCII: true}
+	d := tuitest.NewDriver(t, m).Send(tea.WindowSizeMsg{Width: 80, Height: 40})
+
+	d.Type("down", "enter", "enter", "enter").Snapshot("bomb view").
+		Type("enter").Snapshot("clock").
+		Type("esc", "2").Snapshot("wires").
+		RequireGolden()
+
+	for _, r := range d.Model().View() {
+		if r > unicode.MaxASCII {
+			t.Errorf("view contains %q", r)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ZaneH/defuse.party-tui/internal/client/clienttest"
+	"github.com/ZaneH/defuse.party-tui/internal/profile"
+	"github.com/ZaneH/defuse.party-tui/internal/styles"
+	"github.com/ZaneH/defuse.party-tui/internal/tui/tuitest"
+)
+
+func TestThemeMenu(t *testing.T) {
+	store, err := profile.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	if _, err := store.SetNickname("user:ada", "Ada"); err != nil {
+		t.Fatal(err)
+	}
+	p, err := store.Load("user:ada")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := newTestModel(clienttest.New())
+	m.attachProfile(store, p)
+	d := tuitest.NewDriver(t, m).Send(tea.WindowSizeMsg{Width: 80, Height: 40})
+
+	d.Type("down", "down", "down", "down", "enter")
+	if m.theme.ID != styles.Light.ID || !strings.Contains(m.View(), "> THEME: LIGHT") {
+		t.Fatalf("enter picked theme %q", m.theme.ID)
+	}
+	d.Type("left", "left")
+	if m.theme.ID != styles.Protanopia.ID {
+		t.Fatalf("left picked theme %q", m.theme.ID)
+	}
+
+	saved, err := store.Load("user:ada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Prefs.Theme != styles.Protanopia.ID {
+		t.Fatalf("saved theme = %q, want protanopia", saved.Prefs.Theme)
+	}
+
+	// The next session starts in the saved theme.
+	next := newTestModel(clienttest.New())
+	next.attachProfile(store, saved)
+	if next.theme.ID != styles.Protanopia.ID {
+		t.Errorf("new session theme = %q, want protanopia", next.theme.ID)
+	}
+}
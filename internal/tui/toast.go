@@ -0,0 +1,238 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"google.golang.org/grpc/status"
+
+	"github.com/ZaneH/defuse.party-tui/internal/client"
+	"github.com/ZaneH/defuse.party-tui/internal/styles"
+)
+
+const (
+	maxToastWidth    = 40
+	maxVisibleToasts = 3
+	maxToastHistory  = 50
+	toastLogRows     = 10
+)
+
+type toastLevel int
+
+const (
+	toastInfo toastLevel = iota
+	toastWarning
+	toastError
+)
+
+// ttl is how long a toast stays on screen. More severe toasts linger so
+// they aren't missed mid-defusal.
+func (l toastLevel) ttl() time.Duration {
+	switch l {
+	case toastWarning:
+		return 4 * time.Second
+	case toastError:
+		return 6 * time.Second
+	}
+	return 3 * time.Second
+}
+
+func (l toastLevel) style() lipgloss.Style {
+	switch l {
+	case toastWarning:
+		return styles.Warning
+	case toastError:
+		return styles.Error
+	}
+	return styles.Active
+}
+
+func (l toastLevel) icon() string {
+	switch l {
+	case toastWarning:
+		return "⚠"
+	case toastError:
+		return "✖"
+	}
+	return "ℹ"
+}
+
+type toast struct {
+	id    int
+	level toastLevel
+	text  string
+	at    time.Time
+}
+
+// toasts holds the notifications currently on screen and a scrollback of
+// every notification raised this session.
+type toasts struct {
+	nextID  int
+	active  []toast
+	history []toast
+
+	logOpen   bool
+	logScroll int
+}
+
+// notify shows a toast and schedules its removal.
+func (m *Model) notify(level toastLevel, text string) tea.Cmd {
+	m.toasts.nextID++
+	t := toast{id: m.toasts.nextID, level: level, text: text, at: m.now()}
+
+	m.toasts.active = append(m.toasts.active, t)
+	if len(m.toasts.active) > maxVisibleToasts {
+		m.toasts.active = m.toasts.active[1:]
+	}
+	m.toasts.history = append(m.toasts.history, t)
+	if len(m.toasts.history) > maxToastHistory {
+		m.toasts.history = m.toasts.history[1:]
+	}
+
+	return tea.Tick(level.ttl(), func(time.Time) tea.Msg {
+		return toastExpiredMsg{id: t.id}
+	})
+}
+
+// notifyInputError reports a module input that didn't go through. Failures
+// reaching the backend are errors; inputs the module itself refused, such as
+// cutting a wire twice, are only warnings.
+func (m *Model) notifyInputError(moduleID string, err error) tea.Cmd {
+	level := toastWarning
+	if _, fromRPC := status.FromError(err); fromRPC || errors.Is(err, client.ErrCircuitOpen) {
+		level = toastError
+	}
+
+	name := "MODULE"
+	if mod, ok := m.moduleCache[moduleID]; ok {
+		name = m.moduleTypeName(mod.ModuleType())
+	}
+	return m.notify(level, fmt.Sprintf("%s: %s", name, client.Describe(err)))
+}
+
+func (m *Model) expireToast(id int) {
+	for i, t := range m.toasts.active {
+		if t.id == id {
+			m.toasts.active = append(m.toasts.active[:i], m.toasts.active[i+1:]...)
+			return
+		}
+	}
+}
+
+func (m *Model) handleToastLogKeys(key string) {
+	maxScroll := len(m.toasts.history) - toastLogRows
+	switch key {
+	case "up", "k":
+		if m.toasts.logScroll < maxScroll {
+			m.toasts.logScroll++
+		}
+	case "down", "j":
+		if m.toasts.logScroll > 0 {
+			m.toasts.logScroll--
+		}
+	case "esc", "ctrl+n":
+		m.toasts.logOpen = false
+	}
+}
+
+func (t toast) render() string {
+	text := fmt.Sprintf("%s %s", t.level.icon(), t.text)
+	// Long messages wrap rather than being cut off at the border.
+	text = t.level.style().Width(min(lipgloss.Width(text), maxToastWidth)).Render(text)
+	return styles.Toast.BorderForeground(t.level.style().GetForeground()).Render(text)
+}
+
+// overlayToasts draws the active toasts over the top-right corner of view.
+func (m *Model) overlayToasts(view string) string {
+	if len(m.toasts.active) == 0 {
+		return view
+	}
+
+	rendered := make([]string, 0, len(m.toasts.active))
+	for i := len(m.toasts.active) - 1; i >= 0; i-- {
+		rendered = append(rendered, m.toasts.active[i].render())
+	}
+	return overlay(view, lipgloss.JoinVertical(lipgloss.Right, rendered...), m.width)
+}
+
+// overlay replaces the top-right corner of background with fg, leaving the
+// rest of each affected line intact.
+func overlay(background, fg string, width int) string {
+	lines := strings.Split(background, "\n")
+	if w := lipgloss.Width(background); w > width {
+		width = w
+	}
+	fgWidth := lipgloss.Width(fg)
+	x := max(width-fgWidth, 0)
+
+	for i, fgLine := range strings.Split(fg, "\n") {
+		if i >= len(lines) {
+			lines = append(lines, "")
+		}
+		bg := lines[i]
+		bgWidth := ansi.StringWidth(bg)
+
+		left := ansi.Truncate(bg, x, "")
+		if bgWidth < x {
+			left += strings.Repeat(" ", x-bgWidth)
+		}
+		right := ""
+		if bgWidth > x+fgWidth {
+			right = ansi.TruncateLeft(bg, x+fgWidth, "")
+		}
+		lines[i] = left + fgLine + right
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *Model) toastLogView() string {
+	var rows []string
+	if len(m.toasts.history) == 0 {
+		rows = append(rows, styles.Help.Render("No notifications yet."))
+	}
+
+	// Newest first; scrolling up reveals older entries.
+	now := m.now()
+	end := len(m.toasts.history) - m.toasts.logScroll
+	start := max(end-toastLogRows, 0)
+	for i := end - 1; i >= start; i-- {
+		t := m.toasts.history[i]
+		rows = append(rows, fmt.Sprintf("%s %s",
+			styles.Help.Render(fmt.Sprintf("%8s", toastAge(now.Sub(t.at)))),
+			t.level.style().Render(fmt.Sprintf("%s %s", t.level.icon(), t.text)),
+		))
+	}
+
+	dialog := styles.DialogBox.Render(
+		lipgloss.JoinVertical(
+			lipgloss.Left,
+			styles.Title.Render("NOTIFICATIONS"),
+			"",
+			lipgloss.JoinVertical(lipgloss.Left, rows...),
+			"",
+			styles.Help.Render("[↑/↓] Scroll  [ESC] Close"),
+		),
+	)
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		dialog,
+	)
+}
+
+func toastAge(d time.Duration) string {
+	switch {
+	case d < 5*time.Second:
+		return "just now"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds ago", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh ago", int(d.Hours()))
+}
@@ -0,0 +1,195 @@
+// Package tuitest drives bubbletea models in tests and compares their views
+// against golden files. Run `make golden` to rewrite the golden files after
+// an intentional UI change.
+package tuitest
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+var update = flag.Bool("update", false, "update .golden files")
+
+// tickCmds identifies the commands tea.Tick and tea.Every return, which all
+// share their function's code. They wait on the real clock, so the driver
+// never runs them: animations and countdowns stay put and frames stay
+// deterministic.
+var tickCmds = map[uintptr]bool{
+	reflect.ValueOf(tea.Tick(0, nil)).Pointer():  true,
+	reflect.ValueOf(tea.Every(0, nil)).Pointer(): true,
+}
+
+const maxDepth = 16
+
+// Driver feeds messages to a model the way the bubbletea runtime would:
+// Update is called with each message and any commands it returns are run,
+// with their messages fed back in, before the next message is sent.
+type Driver struct {
+	t      testing.TB
+	model  tea.Model
+	frames []string
+	quit   bool
+}
+
+func NewDriver(t testing.TB, model tea.Model) *Driver {
+	t.Helper()
+	lipgloss.SetColorProfile(termenv.Ascii)
+
+	d := &Driver{t: t, model: model}
+	d.exec(model.Init(), 0)
+	return d
+}
+
+func (d *Driver) Model() tea.Model {
+	return d.model
+}
+
+// Quit reports whether the model asked the program to exit.
+func (d *Driver) Quit() bool {
+	return d.quit
+}
+
+func (d *Driver) Send(msgs ...tea.Msg) *Driver {
+	for _, msg := range msgs {
+		d.send(msg, 0)
+	}
+	return d
+}
+
+// Type sends key presses by name, e.g. "enter", "esc", "up" or "y".
+func (d *Driver) Type(keys ...string) *Driver {
+	for _, key := range keys {
+		d.send(Key(key), 0)
+	}
+	return d
+}
+
+// Snapshot records the current view under label for RequireGolden.
+func (d *Driver) Snapshot(label string) *Driver {
+	d.frames = append(d.frames, fmt.Sprintf("── %s ──\n%s\n", label, d.model.View()))
+	return d
+}
+
+// RequireGolden compares every recorded snapshot with the test's golden file.
+func (d *Driver) RequireGolden() {
+	d.t.Helper()
+	RequireGolden(d.t, strings.Join(d.frames, "\n"))
+}
+
+func (d *Driver) send(msg tea.Msg, depth int) {
+	if d.quit {
+		return
+	}
+	if depth > maxDepth {
+		d.t.Fatalf("message loop did not settle, last message %T", msg)
+	}
+
+	switch msg := msg.(type) {
+	case nil:
+		return
+	case tea.QuitMsg:
+		d.quit = true
+		return
+	case tea.BatchMsg:
+		for _, cmd := range msg {
+			d.exec(cmd, depth)
+		}
+		return
+	}
+
+	// tea.Sequence produces an unexported []tea.Cmd.
+	if v := reflect.ValueOf(msg); v.Kind() == reflect.Slice && v.Type().Elem() == reflect.TypeOf(tea.Cmd(nil)) {
+		for i := 0; i < v.Len(); i++ {
+			d.exec(v.Index(i).Interface().(tea.Cmd), depth)
+		}
+		return
+	}
+
+	var cmd tea.Cmd
+	d.model, cmd = d.model.Update(msg)
+	d.exec(cmd, depth+1)
+}
+
+func (d *Driver) exec(cmd tea.Cmd, depth int) {
+	if cmd == nil || tickCmds[reflect.ValueOf(cmd).Pointer()] {
+		return
+	}
+	d.send(cmd(), depth)
+}
+
+// Key builds the tea.KeyMsg that bubbletea would produce for a key name.
+func Key(name string) tea.KeyMsg {
+	types := map[string]tea.KeyType{
+		"enter":     tea.KeyEnter,
+		"esc":       tea.KeyEsc,
+		"tab":       tea.KeyTab,
+		"backspace": tea.KeyBackspace,
+		"up":        tea.KeyUp,
+		"down":      tea.KeyDown,
+		"left":      tea.KeyLeft,
+		"right":     tea.KeyRight,
+		"pgup":      tea.KeyPgUp,
+		"pgdown":    tea.KeyPgDown,
+		"ctrl+c":    tea.KeyCtrlC,
+		"ctrl+n":    tea.KeyCtrlN,
+		" ":         tea.KeySpace,
+	}
+	if keyType, ok := types[name]; ok {
+		return tea.KeyMsg{Type: keyType}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(name)}
+}
+
+// RequireGolden compares got with testdata/<test name>.golden, rewriting the
+// file instead when -update is set.
+func RequireGolden(t testing.TB, got string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", filepath.FromSlash(t.Name())+".golden")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	if string(want) != got {
+		t.Errorf("view does not match %s (run with -update to accept)\n%s", path, lineDiff(string(want), got))
+	}
+}
+
+func lineDiff(want, got string) string {
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+
+	var sb strings.Builder
+	for i := 0; i < max(len(wantLines), len(gotLines)); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g {
+			fmt.Fprintf(&sb, "line %d:\n- %s\n+ %s\n", i+1, w, g)
+		}
+	}
+	return sb.String()
+}